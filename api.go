@@ -1,8 +1,10 @@
 package fins
 
 import (
+	"context"
 	"encoding/binary"
 	"github.com/expgo/structure"
+	"time"
 )
 
 type FinAddress struct {
@@ -41,6 +43,30 @@ func (fv *FinValue) Uint32() uint32 {
 	return fv.Value().(uint32)
 }
 
+// ForcedWord decodes a word read with forced status (WordFs areas),
+// the first word is the forced status of each bit and the second one is the value.
+// ok is false when the area has no forced status words.
+func (fv *FinValue) ForcedWord() (value, forcedMask uint16, ok bool) {
+	if fv.AreaCode.DataType() != DataTypeWordFs.Val() || len(fv.Buf) < 4 {
+		return 0, 0, false
+	}
+
+	v := binary.BigEndian.Uint32(fv.Buf)
+	return uint16(v), uint16(v >> 16), true
+}
+
+// ForcedBit decodes a bit read with forced status (BitFs and CFFs areas),
+// bit 0 is the bit value and bit 1 is set when it is forced.
+// ok is false when the area has no forced status bits.
+func (fv *FinValue) ForcedBit() (on, forced, ok bool) {
+	dataType := fv.AreaCode.DataType()
+	if dataType != DataTypeBitFs.Val() && dataType != DataTypeCFFs.Val() || len(fv.Buf) < 1 {
+		return false, false, false
+	}
+
+	return fv.Buf[0]&0x01 != 0, fv.Buf[0]&0x02 != 0, true
+}
+
 func (fv *FinValue) SetValue(value any) error {
 	switch fv.AreaCode.Size() {
 	case 1:
@@ -54,11 +80,110 @@ func (fv *FinValue) SetValue(value any) error {
 	return nil
 }
 
+// Fins is the client of a FINS node, each *Context method is the method of the same name stopped when ctx is done.
 type Fins interface {
 	Open() error
+	// OpenContext opens the transporter, ctx bounds the dial and the node address handshake.
+	OpenContext(ctx context.Context) error
 	Close() error
 	Read(address *FinAddress, length uint16) ([]*FinValue, error)
+	// ReadContext is Read aborted when ctx is done, the ctx deadline also applies to the socket write.
+	ReadContext(ctx context.Context, address *FinAddress, length uint16) ([]*FinValue, error)
 	Write(address *FinAddress, values []*FinValue) error
+	WriteContext(ctx context.Context, address *FinAddress, values []*FinValue) error
+	Fill(address *FinAddress, count uint16, value *FinValue) error
+	FillContext(ctx context.Context, address *FinAddress, count uint16, value *FinValue) error
 	RandomRead(addresses []*FinAddress) ([]*FinValue, error)
+	RandomReadContext(ctx context.Context, addresses []*FinAddress) ([]*FinValue, error)
+	Transfer(src, dst *FinAddress, count uint16) error
+	TransferContext(ctx context.Context, src, dst *FinAddress, count uint16) error
+	ListFiles(disk Disk, dir string) (*DiskInfo, []*FileEntry, error)
+	ListFilesContext(ctx context.Context, disk Disk, dir string) (*DiskInfo, []*FileEntry, error)
+	ReadFile(disk Disk, dir, name string) ([]byte, error)
+	ReadFileContext(ctx context.Context, disk Disk, dir, name string) ([]byte, error)
+	WriteFile(disk Disk, dir, name string, data []byte) error
+	WriteFileContext(ctx context.Context, disk Disk, dir, name string, data []byte) error
+	DeleteFiles(disk Disk, dir string, names ...string) error
+	DeleteFilesContext(ctx context.Context, disk Disk, dir string, names ...string) error
+	CopyFile(srcDisk Disk, srcDir, srcName string, dstDisk Disk, dstDir, dstName string) error
+	CopyFileContext(ctx context.Context, srcDisk Disk, srcDir, srcName string, dstDisk Disk, dstDir, dstName string) error
+	RenameFile(disk Disk, dir, oldName, newName string) error
+	RenameFileContext(ctx context.Context, disk Disk, dir, oldName, newName string) error
+	CreateDirectory(disk Disk, dir, name string) error
+	CreateDirectoryContext(ctx context.Context, disk Disk, dir, name string) error
+	DeleteDirectory(disk Disk, dir, name string) error
+	DeleteDirectoryContext(ctx context.Context, disk Disk, dir, name string) error
+	FormatFileMemory(disk Disk) error
+	FormatFileMemoryContext(ctx context.Context, disk Disk) error
+	ForceSet(address *FinAddress) error
+	ForceSetContext(ctx context.Context, address *FinAddress) error
+	ForceReset(address *FinAddress) error
+	ForceResetContext(ctx context.Context, address *FinAddress) error
+	ForceCancelAll() error
+	ForceCancelAllContext(ctx context.Context) error
+	Force(ops []ForceOp) error
+	ForceContext(ctx context.Context, ops []ForceOp) error
+	ReadParameterArea(area ParameterArea) ([]byte, error)
+	ReadParameterAreaContext(ctx context.Context, area ParameterArea) ([]byte, error)
+	WriteParameterArea(area ParameterArea, data []byte) error
+	WriteParameterAreaContext(ctx context.Context, area ParameterArea, data []byte) error
+	ClearParameterArea(area ParameterArea) error
+	ClearParameterAreaContext(ctx context.Context, area ParameterArea) error
+	ReadProgram(offset uint32, length uint16) (data []byte, last bool, err error)
+	ReadProgramContext(ctx context.Context, offset uint32, length uint16) (data []byte, last bool, err error)
+	WriteProgram(offset uint32, data []byte, last bool) error
+	WriteProgramContext(ctx context.Context, offset uint32, data []byte, last bool) error
+	ClearProgram() error
+	ClearProgramContext(ctx context.Context) error
+	Run(mode OperatingMode) error
+	RunContext(ctx context.Context, mode OperatingMode) error
+	Stop() error
+	StopContext(ctx context.Context) error
+	ControllerData() (*ControllerData, error)
+	ControllerDataContext(ctx context.Context) (*ControllerData, error)
+	ControllerStatus() (*ControllerStatus, error)
+	ControllerStatusContext(ctx context.Context) (*ControllerStatus, error)
+	CycleTime() (*CycleTime, error)
+	CycleTimeContext(ctx context.Context) (*CycleTime, error)
+	NetworkStatus() (*NetworkStatus, error)
+	NetworkStatusContext(ctx context.Context) (*NetworkStatus, error)
+	DataLinkStatus() (*DataLinkStatus, error)
+	DataLinkStatusContext(ctx context.Context) (*DataLinkStatus, error)
+	InitCycleTime() error
+	InitCycleTimeContext(ctx context.Context) error
+	Loopback(payload []byte) (time.Duration, error)
+	LoopbackContext(ctx context.Context, payload []byte) (time.Duration, error)
+	BroadcastTestSend(payload []byte) error
+	BroadcastTestSendContext(ctx context.Context, payload []byte) error
+	BroadcastTestResults() (uint16, error)
+	BroadcastTestResultsContext(ctx context.Context) (uint16, error)
+	ReadClock() (time.Time, error)
+	ReadClockContext(ctx context.Context) (time.Time, error)
+	WriteClock(t time.Time) error
+	WriteClockContext(ctx context.Context, t time.Time) error
+	AcquireAccessRight() error
+	AcquireAccessRightContext(ctx context.Context) error
+	ForceAcquireAccessRight() error
+	ForceAcquireAccessRightContext(ctx context.Context) error
+	ReleaseAccessRight() error
+	ReleaseAccessRightContext(ctx context.Context) error
+	ClearError(code uint16) error
+	ClearErrorContext(ctx context.Context, code uint16) error
+	ReadMessages() ([]*Message, error)
+	ReadMessagesContext(ctx context.Context) ([]*Message, error)
+	ClearMessages(mask byte) error
+	ClearMessagesContext(ctx context.Context, mask byte) error
+	ReadFALMessages() (*FALMessage, error)
+	ReadFALMessagesContext(ctx context.Context) (*FALMessage, error)
+	ReadErrorLog(start, count uint16) ([]*ErrorLogRecord, error)
+	ReadErrorLogContext(ctx context.Context, start, count uint16) ([]*ErrorLogRecord, error)
+	ClearErrorLog() error
+	ClearErrorLogContext(ctx context.Context) error
 	SetStateChangeCallback(callback func(oldState, newState State))
+	// Route returns a Fins sending all its requests to dst, e.g. a plc behind a gateway on a remote
+	// network, over the same connection. Open and Close of the returned Fins act on that connection.
+	Route(dst NodeAddress) (Fins, error)
+	// SetCpuErrorCallback sets the callback called when a successful response reports
+	// a fatal or non-fatal cpu unit error, the response data is still returned.
+	SetCpuErrorCallback(callback func(err *FinsError))
 }
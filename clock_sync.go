@@ -0,0 +1,145 @@
+package fins
+
+import (
+	"context"
+	"github.com/expgo/factory"
+	"github.com/expgo/log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ClockSyncResult struct {
+	// Drift is the PLC clock minus the host clock, positive when the PLC is ahead
+	Drift     time.Duration
+	RoundTrip time.Duration
+	Adjusted  bool
+}
+
+// ClockSync keeps the PLC clock aligned with the host clock.
+//
+// The PLC clock is checked every Interval and right after each reconnect,
+// it is rewritten when the drift exceeds Threshold.
+type ClockSync struct {
+	log.InnerLog
+	Interval  time.Duration `value:"1h"`
+	Threshold time.Duration `value:"2s"`
+
+	fins     Fins
+	callback func(oldState, newState State)
+	lock     sync.Mutex
+	// ctx is canceled by Stop, it aborts the sync in progress
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running atomic.Bool
+}
+
+func NewClockSync(f Fins) *ClockSync {
+	ret := factory.New[ClockSync]()
+	ret.fins = f
+	return ret
+}
+
+// SetStateChangeCallback sets the callback chained after the clock sync one,
+// use it instead of Fins.SetStateChangeCallback while ClockSync is started.
+func (cs *ClockSync) SetStateChangeCallback(callback func(oldState, newState State)) {
+	cs.callback = callback
+}
+
+func (cs *ClockSync) Start() {
+	if !cs.running.CompareAndSwap(false, true) {
+		return
+	}
+
+	cs.ctx, cs.cancel = context.WithCancel(context.Background())
+	cs.fins.SetStateChangeCallback(cs.onStateChange)
+
+	go cs.loop(cs.ctx)
+}
+
+func (cs *ClockSync) Stop() {
+	if !cs.running.CompareAndSwap(true, false) {
+		return
+	}
+
+	cs.fins.SetStateChangeCallback(cs.callback)
+	cs.cancel()
+}
+
+func (cs *ClockSync) loop(ctx context.Context) {
+	cs.syncAndLog(ctx)
+
+	if cs.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(cs.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cs.syncAndLog(ctx)
+		}
+	}
+}
+
+func (cs *ClockSync) onStateChange(oldState, newState State) {
+	if cs.callback != nil {
+		cs.callback(oldState, newState)
+	}
+
+	// the callback is called with the transporter state locked, so sync in background
+	if newState == StateConnected {
+		go cs.syncAndLog(cs.ctx)
+	}
+}
+
+func (cs *ClockSync) syncAndLog(ctx context.Context) {
+	ret, err := cs.SyncContext(ctx)
+	if err != nil {
+		cs.L.Warnf("clock sync failed: %v", err)
+		return
+	}
+
+	if ret.Adjusted {
+		cs.L.Infof("plc clock adjusted, drift: %v, round trip: %v", ret.Drift, ret.RoundTrip)
+	}
+}
+
+// Sync compares the PLC clock with the host clock once and rewrites it if needed.
+func (cs *ClockSync) Sync() (*ClockSyncResult, error) {
+	return cs.SyncContext(context.Background())
+}
+
+func (cs *ClockSync) SyncContext(ctx context.Context) (*ClockSyncResult, error) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	start := time.Now()
+	plcTime, err := cs.fins.ReadClockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &ClockSyncResult{RoundTrip: time.Since(start)}
+
+	// the PLC clock only has second resolution, compare with the truncated host time
+	hostTime := start.Add(ret.RoundTrip / 2).Truncate(time.Second)
+	ret.Drift = plcTime.Sub(hostTime)
+
+	if ret.Drift <= cs.Threshold && ret.Drift >= -cs.Threshold {
+		return ret, nil
+	}
+
+	err = cs.fins.WriteClockContext(ctx, time.Now().Add(ret.RoundTrip/2))
+	if err != nil {
+		return ret, err
+	}
+
+	ret.Adjusted = true
+
+	return ret, nil
+}
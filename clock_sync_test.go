@@ -0,0 +1,60 @@
+package fins
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestClockSync(t *testing.T) {
+	f, mt := newMockFins(encodeClock(time.Now().Add(-time.Hour)))
+
+	cs := NewClockSync(f)
+	assert.Equal(t, time.Hour, cs.Interval)
+
+	ret, err := cs.Sync()
+	assert.NoError(t, err)
+	assert.True(t, ret.Adjusted)
+	assert.InDelta(t, float64(-time.Hour), float64(ret.Drift), float64(2*time.Second))
+	assert.Equal(t, []byte{0x07, 0x02}, mt.req[:2])
+
+	mt.push(encodeClock(time.Now()))
+	ret, err = cs.Sync()
+	assert.NoError(t, err)
+	assert.False(t, ret.Adjusted)
+	assert.Equal(t, []byte{0x07, 0x01}, mt.req)
+}
+
+func TestClockSyncReconnect(t *testing.T) {
+	f, mt := newMockFins(encodeClock(time.Now()))
+
+	var states []State
+	cs := NewClockSync(f)
+	cs.Interval = 0
+	cs.SetStateChangeCallback(func(oldState, newState State) {
+		states = append(states, newState)
+	})
+
+	cs.Start()
+	// the first sync finds no drift and only reads the clock
+	assert.Eventually(t, func() bool { return len(mt.requests()) == 1 }, time.Second, 10*time.Millisecond)
+
+	mt.push(encodeClock(time.Now().Add(-time.Hour)))
+	mt.setState(StateDisconnected, nil)
+	mt.setState(StateConnected, nil)
+	assert.Equal(t, []State{StateDisconnected, StateConnected}, states)
+
+	// the reconnect syncs again and rewrites the drifted clock
+	assert.Eventually(t, func() bool { return len(mt.requests()) == 3 }, time.Second, 10*time.Millisecond)
+	reqs := mt.requests()
+	assert.Equal(t, []byte{0x07, 0x01}, reqs[1])
+	assert.Equal(t, []byte{0x07, 0x02}, reqs[2][:2])
+
+	// Stop restores the chained callback, which no longer syncs
+	cs.Stop()
+	mt.setState(StateDisconnected, nil)
+	mt.setState(StateConnected, nil)
+	assert.Equal(t, []State{StateDisconnected, StateConnected, StateDisconnected, StateConnected}, states)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, mt.requests(), 3)
+}
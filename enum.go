@@ -1,6 +1,9 @@
 package fins
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 /*
 PlcType
@@ -106,6 +109,18 @@ MemoryArea word is big end
 */
 type MemoryArea string
 
+/*
+ParameterArea words is the size of the area in CS/CJ-series
+
+	@Enum(code uint16, words uint16) {
+		PlcSetup(0x8010, 512)
+		IOTable(0x8012, 1280)
+		RoutingTable(0x8013, 512)
+		CpuBusUnitSetup(0x8002, 5428)
+	}
+*/
+type ParameterArea int
+
 /*
 Command
 
@@ -115,10 +130,128 @@ Command
 		MemoryFill(1, 3)
 		MultipleMemoryRead(1, 4)
 		MemoryTransfer(1, 5)
+		ParameterAreaRead(2, 1)
+		ParameterAreaWrite(2, 2)
+		ParameterAreaClear(2, 3)
+		ProgramAreaRead(3, 6)
+		ProgramAreaWrite(3, 7)
+		ProgramAreaClear(3, 8)
+		Run(4, 1)
+		Stop(4, 2)
+		ControllerDataRead(5, 1)
+		ControllerStatusRead(6, 1)
+		NetworkStatusRead(6, 2)
+		DataLinkStatusRead(6, 3)
+		CycleTimeRead(6, 0x20)
+		ClockRead(7, 1)
+		ClockWrite(7, 2)
+		LoopbackTest(8, 1)
+		BroadcastTestResultsRead(8, 2)
+		BroadcastTestDataSend(8, 3)
+		MessageRead(9, 0x20)
+		AccessRightAcquire(0x0C, 1)
+		AccessRightForcedAcquire(0x0C, 2)
+		AccessRightRelease(0x0C, 3)
+		ErrorClear(0x21, 1)
+		ErrorLogRead(0x21, 2)
+		ErrorLogClear(0x21, 3)
+		FileNameRead(0x22, 1)
+		SingleFileRead(0x22, 2)
+		SingleFileWrite(0x22, 3)
+		FileMemoryFormat(0x22, 4)
+		FileDelete(0x22, 5)
+		FileCopy(0x22, 7)
+		FileNameChange(0x22, 8)
+		DirectoryCreateDelete(0x22, 0x15)
+		ForcedSetReset(0x23, 1)
+		ForcedSetResetCancel(0x23, 2)
 	}
 */
 type Command int
 
+/*
+Disk
+
+	@Enum {
+		MemoryCard = 0x8000
+		EMFile     = 0x8001
+	}
+*/
+type Disk uint16
+
+/*
+ForceAction
+
+	@Enum {
+		Reset      = 0x0000
+		Set        = 0x0001
+		ReleaseOff = 0x8000
+		ReleaseOn  = 0x8001
+		Release    = 0xFFFF
+	}
+*/
+type ForceAction uint16
+
+/*
+OperatingMode
+
+	@Enum {
+		Program = 0x00
+		Debug   = 0x01
+		Monitor = 0x02
+		Run     = 0x04
+	}
+*/
+type OperatingMode byte
+
+/*
+CpuStatus
+
+	@Enum {
+		Stop    = 0x00
+		Run     = 0x01
+		Standby = 0x80
+	}
+*/
+type CpuStatus byte
+
+// commandOf returns the Command of a command code.
+func commandOf(code [2]byte) (Command, bool) {
+	for cmd, mr := range _CommandMapMr {
+		if mr == code[0] && cmd.Sr() == code[1] {
+			return cmd, true
+		}
+	}
+
+	return 0, false
+}
+
+func (pt PlcType) CheckRange(address *FinAddress, count uint16) error {
+	if count == 0 {
+		return errors.New("count must be greater than 0")
+	}
+
+	var max uint16
+	switch pt {
+	case PlcTypeNew:
+		max = address.AreaCode.Max()
+	case PlcTypeOld:
+		// areas without a CV-series equivalent are declared with old code 0 and old max -1
+		if address.AreaCode.OldCode() == 0 && address.AreaCode.OldMax() == 0xFFFF {
+			return fmt.Errorf("area %s not supported by %s", address.AreaCode, pt.Description())
+		}
+		max = address.AreaCode.OldMax()
+	default:
+		return errors.New("invalid PlcType")
+	}
+
+	if uint32(address.Address)+uint32(count)-1 > uint32(max) {
+		return errors.New("address range exceeded")
+	}
+
+	return nil
+}
+
 func (pt PlcType) EncodeAddress(address *FinAddress) (ret [4]byte, err error) {
 	ac := address.AreaCode
 	if ac.DataType() == DataTypeBit.Val() {
@@ -11,3 +11,11 @@ func TestEncodeAddress(t *testing.T) {
 	assert.NoError(t, err, "EncodeAddress")
 	assert.Equal(t, [4]byte{0x30, 0x0, 0x0a, 0x0d}, addr)
 }
+
+func TestCheckRange(t *testing.T) {
+	assert.NoError(t, PlcTypeNew.CheckRange(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 32760}, 8))
+	assert.Error(t, PlcTypeNew.CheckRange(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 32760}, 9))
+	assert.Error(t, PlcTypeOld.CheckRange(&FinAddress{AreaCode: MemoryAreaCIOWord, Address: 2555}, 2))
+	assert.Error(t, PlcTypeOld.CheckRange(&FinAddress{AreaCode: MemoryAreaWRWord, Address: 0}, 1))
+	assert.Error(t, PlcTypeOld.CheckRange(&FinAddress{AreaCode: MemoryAreaHRWord, Address: 60000}, 100))
+}
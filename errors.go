@@ -31,8 +31,150 @@ var (
 	NetWorkRelayError    = errors.New("network relay error")
 	FatalCpuUnitError    = errors.New("fatal cpu unit error")
 	NonFatalCpuUnitError = errors.New("non-fatal cpu unit error")
+
+	NotExecutableInCurrentModeError = errors.New("not executable in current mode")
+	CannotStartStopError            = errors.New("cannot start/stop")
+	NoAccessRightError              = errors.New("no access right")
+)
+
+// sentinel errors of common end codes, match them with errors.Is
+var (
+	ServiceCanceledError      = &FinsError{EndCode: EndCode{0x00, 0x01}}
+	DestinationNodeBusyError  = &FinsError{EndCode: EndCode{0x02, 0x04}}
+	ResponseTimeoutError      = &FinsError{EndCode: EndCode{0x02, 0x05}}
+	UndefinedCommandError     = &FinsError{EndCode: EndCode{0x04, 0x01}}
+	AddressRangeError         = &FinsError{EndCode: EndCode{0x11, 0x03}}
+	AddressRangeExceededError = &FinsError{EndCode: EndCode{0x11, 0x04}}
+	ReadProtectedError        = &FinsError{EndCode: EndCode{0x20, 0x02}}
+	ReadOnlyError             = &FinsError{EndCode: EndCode{0x21, 0x01}}
+	NoSuchFileDeviceError     = &FinsError{EndCode: EndCode{0x23, 0x01}}
+	CommandProtectedError     = &FinsError{EndCode: EndCode{0x26, 0x04}}
 )
 
+// FinsError is the error of a response whose end code is not normal completion.
+//
+// errors.Is matches a FinsError with an MC main code, a sentinel FinsError with the same
+// main and sub code, and the relay, cpu unit and other error variables declared above.
+type FinsError struct {
+	EndCode     EndCode
+	CommandCode [2]byte
+	SID         byte
+}
+
+func (e *FinsError) MainCode() MC {
+	return MC(e.EndCode.MainCode())
+}
+
+func (e *FinsError) SubCode() byte {
+	return e.EndCode.SubCode()
+}
+
+func (e *FinsError) NetWorkRelayError() bool {
+	return e.EndCode.NetWorkRelayError()
+}
+
+func (e *FinsError) FatalCpuUnitError() bool {
+	return e.EndCode.FatalCpuUnitError()
+}
+
+func (e *FinsError) NonFatalCpuUnitError() bool {
+	return e.EndCode.NonFatalCpuUnitError()
+}
+
+// Text returns the description of the main and sub code.
+func (e *FinsError) Text() string {
+	if subMap, ok := errorsMap[e.MainCode().Val()]; ok {
+		if errStr, ok1 := subMap[e.SubCode()]; ok1 {
+			return errStr
+		}
+	}
+
+	return fmt.Sprintf("unknown end-code 0x%02x:0x%02x", e.MainCode().Val(), e.SubCode())
+}
+
+// Temporary reports whether the error is caused by the network or a busy node, so the command may be retried.
+func (e *FinsError) Temporary() bool {
+	switch e.MainCode() {
+	case MCLocalNodeError:
+		return e.SubCode() >= 0x02 && e.SubCode() <= 0x04
+	case MCDestinationNodeError:
+		return e.SubCode() == 0x01 || e.SubCode() == 0x04 || e.SubCode() == 0x05
+	case MCAbort:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *FinsError) Error() string {
+	msg := e.Text()
+	if e.MainCode() == MCNormalCompletion && e.SubCode() == 0 {
+		msg = "normal completion"
+	}
+
+	if e.CommandCode != [2]byte{} {
+		cmd := fmt.Sprintf("%02x%02x", e.CommandCode[0], e.CommandCode[1])
+		if c, ok := commandOf(e.CommandCode); ok {
+			cmd = c.Name() + "(" + cmd + ")"
+		}
+		msg = fmt.Sprintf("%s sid %d: %s", cmd, e.SID, msg)
+	}
+
+	if e.NetWorkRelayError() {
+		msg += ", " + NetWorkRelayError.Error()
+	}
+	if e.FatalCpuUnitError() {
+		msg += ", " + FatalCpuUnitError.Error()
+	}
+	if e.NonFatalCpuUnitError() {
+		msg += ", " + NonFatalCpuUnitError.Error()
+	}
+
+	return msg
+}
+
+func (e *FinsError) Is(target error) bool {
+	switch t := target.(type) {
+	case MC:
+		return e.MainCode() == t
+	case *FinsError:
+		return e.MainCode() == t.MainCode() && e.SubCode() == t.SubCode()
+	}
+
+	switch target {
+	case NetWorkRelayError:
+		return e.NetWorkRelayError()
+	case FatalCpuUnitError:
+		return e.FatalCpuUnitError()
+	case NonFatalCpuUnitError:
+		return e.NonFatalCpuUnitError()
+	case NotExecutableInCurrentModeError:
+		return e.MainCode() == MCNotExecutableInCurrentMode
+	case CannotStartStopError:
+		return e.MainCode() == MCCannotStartStop
+	case NoAccessRightError:
+		return e.MainCode() == MCAccessRightError
+	default:
+		return false
+	}
+}
+
+// AccessRightError is returned when the access right is held by another node.
+type AccessRightError struct {
+	*FinsError
+	Network byte
+	Node    byte
+	Unit    byte
+}
+
+func (e *AccessRightError) Error() string {
+	return fmt.Sprintf("%v, held by network %d node %d unit 0x%02x", e.FinsError, e.Network, e.Node, e.Unit)
+}
+
+func (e *AccessRightError) Unwrap() error {
+	return e.FinsError
+}
+
 /*
 MC
 
@@ -58,6 +200,11 @@ MC
 */
 type MC byte
 
+// Error makes MC usable as an errors.Is target for FinsError.
+func (x MC) Error() string {
+	return x.Name()
+}
+
 var errorsMap = map[byte]map[byte]string{
 	MCNormalCompletion.Val(): {
 		0x01: "Service canceled",
@@ -179,28 +326,54 @@ var errorsMap = map[byte]map[byte]string{
 	},
 }
 
+// Error returns a *FinsError when the main or sub code is not normal completion,
+// the cpu unit error bits alone don't fail a command as its data is still valid.
 func (e EndCode) Error() error {
-	if e[0] == 0 && e[1] == 0 {
+	if e.MainCode() == 0 && e.SubCode() == 0 {
 		return nil
 	}
 
-	if e.NetWorkRelayError() {
-		return NetWorkRelayError
-	}
+	return &FinsError{EndCode: e}
+}
 
-	if e.FatalCpuUnitError() {
-		return FatalCpuUnitError
-	}
+// cpuErrorsMap is the CS/CJ-series CPU Unit error codes stored in the error log
+var cpuErrorsMap = map[uint16]string{
+	0x0008: "Power interruption",
+	0x008A: "Interrupt task error",
+	0x009A: "Basic I/O error",
+	0x009B: "PLC Setup error",
+	0x00E7: "I/O verification error",
+	0x00F7: "Battery error",
+	0x809F: "Cycle time too long",
+	0x80E0: "I/O setting error",
+	0x80E1: "Too many I/O points",
+	0x80E9: "Duplicate number error",
+	0x80F0: "Program error",
+	0x80F1: "Memory error",
+}
 
-	if e.NonFatalCpuUnitError() {
-		return NonFatalCpuUnitError
+// CpuErrorText returns the description of a CPU Unit error code as stored in the error log.
+func CpuErrorText(code uint16) string {
+	if text, ok := cpuErrorsMap[code]; ok {
+		return text
 	}
 
-	if subMap, ok := errorsMap[e.MainCode()]; ok {
-		if errStr, ok1 := subMap[e.SubCode()]; ok1 {
-			return errors.New(errStr)
-		}
+	switch {
+	case code >= 0x0200 && code <= 0x020F:
+		return fmt.Sprintf("CPU Bus Unit %d error", code-0x0200)
+	case code >= 0x0300 && code <= 0x035F:
+		return fmt.Sprintf("Special I/O Unit %d error", code-0x0300)
+	case code >= 0x0400 && code <= 0x040F:
+		return fmt.Sprintf("CPU Bus Unit %d setting error", code-0x0400)
+	case code >= 0x0500 && code <= 0x055F:
+		return fmt.Sprintf("Special I/O Unit %d setting error", code-0x0500)
+	case code >= 0x4101 && code <= 0x42FF:
+		return fmt.Sprintf("FAL %d executed", code-0x4100)
+	case code >= 0x80C0 && code <= 0x80CF:
+		return "I/O bus error"
+	case code >= 0xC101 && code <= 0xC2FF:
+		return fmt.Sprintf("FALS %d executed", code-0xC100)
 	}
 
-	return fmt.Errorf("unknown end-code 0x%02x:0x%02x", e.MainCode(), e.SubCode())
+	return fmt.Sprintf("unknown error code 0x%04x", code)
 }
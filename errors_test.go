@@ -0,0 +1,46 @@
+package fins
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFinsError(t *testing.T) {
+	assert.NoError(t, EndCode{0x00, 0x00}.Error())
+	assert.NoError(t, EndCode{0x00, 0x40}.Error())
+	assert.NoError(t, EndCode{0x00, 0x80}.Error())
+
+	err := &FinsError{EndCode: EndCode{0x11, 0x44}, CommandCode: [2]byte{0x01, 0x01}, SID: 7}
+	assert.Equal(t, "MemoryRead(0101) sid 7: Address range exceeded, non-fatal cpu unit error", err.Error())
+	assert.ErrorIs(t, err, AddressRangeExceededError)
+	assert.ErrorIs(t, err, MCParameterError)
+	assert.ErrorIs(t, err, NonFatalCpuUnitError)
+	assert.NotErrorIs(t, err, ReadOnlyError)
+	assert.NotErrorIs(t, err, FatalCpuUnitError)
+	assert.False(t, err.Temporary())
+
+	wrapped := error(&AccessRightError{FinsError: &FinsError{EndCode: EndCode{0x30, 0x01}}, Node: 3})
+	assert.ErrorIs(t, wrapped, NoAccessRightError)
+
+	var finsErr *FinsError
+	assert.True(t, errors.As(wrapped, &finsErr))
+	assert.Equal(t, MCAccessRightError, finsErr.MainCode())
+
+	assert.True(t, (&FinsError{EndCode: EndCode{0x02, 0x05}}).Temporary())
+}
+
+func TestFinsErrorProtected(t *testing.T) {
+	readErr := EndCode{0x20, 0x02}.Error()
+	assert.ErrorIs(t, readErr, ReadProtectedError)
+	assert.NotErrorIs(t, readErr, CommandProtectedError)
+
+	cmdErr := EndCode{0x26, 0x04}.Error()
+	assert.ErrorIs(t, cmdErr, CommandProtectedError)
+	assert.NotErrorIs(t, cmdErr, ReadProtectedError)
+
+	noCard := EndCode{0x23, 0x01}.Error()
+	assert.ErrorIs(t, noCard, NoSuchFileDeviceError)
+	assert.NotErrorIs(t, noCard, ReadProtectedError)
+	assert.NotErrorIs(t, noCard, CommandProtectedError)
+}
@@ -0,0 +1,206 @@
+package fins
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// FileFS is a read only fs.FS view over the file memory of a PLC,
+// empty files without extension are shown as directories, see FileEntry.IsDir.
+type FileFS struct {
+	fins Fins
+	disk Disk
+}
+
+var (
+	_ fs.ReadDirFS  = (*FileFS)(nil)
+	_ fs.ReadFileFS = (*FileFS)(nil)
+)
+
+func NewFileFS(f Fins, disk Disk) *FileFS {
+	return &FileFS{fins: f, disk: disk}
+}
+
+func (ffs *FileFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		entries, err := ffs.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &fileFSDir{info: fileFSInfo{entry: &FileEntry{Name: ".", dir: true}}, entries: entries}, nil
+	}
+
+	entry, err := ffs.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	info := fileFSInfo{entry: entry}
+	if entry.IsDir() {
+		entries, err := ffs.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &fileFSDir{info: info, entries: entries}, nil
+	}
+
+	data, err := ffs.fins.ReadFile(ffs.disk, path.Dir(name), entry.Name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &fileFSFile{info: info, Reader: bytes.NewReader(data)}, nil
+}
+
+func (ffs *FileFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	_, files, err := ffs.fins.ListFiles(ffs.disk, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(files))
+	for _, file := range files {
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+		entries = append(entries, fileFSInfo{entry: file})
+	}
+
+	// the PLC returns the files in storage order, fs.ReadDirFS requires them sorted by name
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
+
+func (ffs *FileFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+
+	data, err := ffs.fins.ReadFile(ffs.disk, path.Dir(name), path.Base(name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+
+	return data, nil
+}
+
+func (ffs *FileFS) stat(name string) (*FileEntry, error) {
+	_, files, err := ffs.fins.ListFiles(ffs.disk, path.Dir(name))
+	if err != nil {
+		return nil, err
+	}
+
+	base := path.Base(name)
+	for _, file := range files {
+		if file.Name == base {
+			return file, nil
+		}
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+// fileFSInfo implements both fs.FileInfo and fs.DirEntry.
+type fileFSInfo struct {
+	entry *FileEntry
+}
+
+func (fi fileFSInfo) Name() string {
+	return fi.entry.Name
+}
+
+func (fi fileFSInfo) Size() int64 {
+	return int64(fi.entry.Size)
+}
+
+func (fi fileFSInfo) Mode() fs.FileMode {
+	if fi.entry.IsDir() {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+func (fi fileFSInfo) ModTime() time.Time {
+	return fi.entry.ModTime
+}
+
+func (fi fileFSInfo) IsDir() bool {
+	return fi.entry.IsDir()
+}
+
+func (fi fileFSInfo) Sys() any {
+	return fi.entry
+}
+
+func (fi fileFSInfo) Type() fs.FileMode {
+	return fi.Mode().Type()
+}
+
+func (fi fileFSInfo) Info() (fs.FileInfo, error) {
+	return fi, nil
+}
+
+type fileFSFile struct {
+	*bytes.Reader
+	info fileFSInfo
+}
+
+func (f *fileFSFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *fileFSFile) Close() error {
+	return nil
+}
+
+type fileFSDir struct {
+	info    fileFSInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *fileFSDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *fileFSDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+func (d *fileFSDir) Close() error {
+	return nil
+}
+
+func (d *fileFSDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+
+	return rest[:n], nil
+}
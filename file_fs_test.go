@@ -0,0 +1,80 @@
+package fins
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// fakeFileFins serves the file memory commands used by FileFS from memory.
+type fakeFileFins struct {
+	Fins
+	dirs  map[string][]*FileEntry
+	files map[string][]byte
+}
+
+func newFakeFileFins() *fakeFileFins {
+	modTime := time.Date(2024, 4, 21, 12, 0, 0, 0, time.Local)
+	return &fakeFileFins{
+		dirs: map[string][]*FileEntry{
+			".": {
+				{Name: "ZETA.TXT", Size: 4, ModTime: modTime},
+				{Name: "ALPHA.CSV", Size: 5, ModTime: modTime},
+				{Name: "LOGS", ModTime: modTime},
+			},
+			"LOGS": {
+				{Name: ".", ModTime: modTime},
+				{Name: "..", ModTime: modTime},
+				{Name: "B.LOG", Size: 2, ModTime: modTime},
+				{Name: "A.LOG", Size: 3, ModTime: modTime},
+			},
+		},
+		files: map[string][]byte{
+			"ZETA.TXT":   []byte("zeta"),
+			"ALPHA.CSV":  []byte("a,b,c"),
+			"LOGS/B.LOG": []byte("b\n"),
+			"LOGS/A.LOG": []byte("aa\n"),
+		},
+	}
+}
+
+func (f *fakeFileFins) ListFiles(_ Disk, dir string) (*DiskInfo, []*FileEntry, error) {
+	entries, ok := f.dirs[dir]
+	if !ok {
+		return nil, nil, fs.ErrNotExist
+	}
+	return &DiskInfo{}, entries, nil
+}
+
+func (f *fakeFileFins) ReadFile(_ Disk, dir, name string) ([]byte, error) {
+	data, ok := f.files[path.Join(dir, name)]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	// like the PLC, return a fresh copy on every read
+	return append([]byte(nil), data...), nil
+}
+
+func TestFileFSWalkDir(t *testing.T) {
+	ffs := NewFileFS(newFakeFileFins(), DiskMemoryCard)
+
+	info, err := fs.Stat(ffs, ".")
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	var walked []string
+	err = fs.WalkDir(ffs, ".", func(name string, d fs.DirEntry, err error) error {
+		walked = append(walked, name)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".", "ALPHA.CSV", "LOGS", "LOGS/A.LOG", "LOGS/B.LOG", "ZETA.TXT"}, walked)
+}
+
+func TestFileFS(t *testing.T) {
+	err := fstest.TestFS(NewFileFS(newFakeFileFins(), DiskMemoryCard), "ZETA.TXT", "ALPHA.CSV", "LOGS/A.LOG", "LOGS/B.LOG")
+	assert.NoError(t, err)
+}
@@ -2,13 +2,16 @@ package fins
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/expgo/factory"
 	"github.com/expgo/log"
 	"github.com/expgo/structure"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 /*
@@ -57,7 +60,7 @@ func newFinsHeader(dc DataClass, requireResp bool, sid byte) *finsHeader {
 		ret.ICF = ret.ICF | 0b00000001
 	}
 
-	// Set GCT: fix 2 or 7 if across up to 8 network layers
+	// GCT: the default of 2 is replaced by the gateway count of the options in newHeader
 	ret.GCT = 2
 
 	/*
@@ -65,7 +68,7 @@ func newFinsHeader(dc DataClass, requireResp bool, sid byte) *finsHeader {
 		00: 		Local network
 		01 to 7F: 	Remote network address (decimal: 1 to 127)
 	*/
-	ret.DNA = 0x00 // set to Local network
+	ret.DNA = 0x00 // local network, newHeader applies the destination of the options or Route
 
 	/*
 		Destination node address. Specify within the following ranges (hex).
@@ -116,23 +119,67 @@ func newFinsHeader(dc DataClass, requireResp bool, sid byte) *finsHeader {
 	return ret
 }
 
+type response struct {
+	header *respFinsHeader
+	data   []byte
+	err    error
+}
+
 type fins struct {
 	log.InnerLog
 	plcType     PlcType
 	transporter Transporter
-	sid         atomic.Uint32
+	options     *options
+	// dst overrides the destination of the options for the Fins returned by Route
+	dst *NodeAddress
+	*mux
+}
+
+// mux multiplexes the requests of a fins and its routes on the transporter by SID,
+// it also holds the cpu error callback they share.
+type mux struct {
+	sid atomic.Uint32
+	// writeLock serializes the frames written to the transporter
+	writeLock sync.Mutex
+	// pending holds the callers waiting for a response, keyed by SID
+	pending     map[byte]chan *response
+	pendingLock sync.Mutex
+	reading     bool
+	// timeouts counts the consecutive response timeouts
+	timeouts atomic.Int32
+
+	cpuErrorCallback func(err *FinsError)
 }
 
-func NewFins(plcType PlcType, transType TransType, addr string) Fins {
+// ErrClosed is returned by the requests of a closed Fins.
+var ErrClosed = errors.New("fins closed")
+
+// maxResponseTimeouts consecutive response timeouts mark the transporter disconnected,
+// a half-open connection never fails the reader, so it is only detected by them.
+const maxResponseTimeouts = 3
+
+// NewFins creates a Fins of the plc at addr, it panics when the options are invalid.
+func NewFins(plcType PlcType, transType TransType, addr string, opts ...Option) Fins {
+	o, err := newOptions(opts)
+	if err != nil {
+		panic(err)
+	}
+
 	ret := factory.New[fins]()
 
 	ret.plcType = plcType
+	ret.options = o
+	ret.mux = &mux{}
 
 	switch transType {
 	case TransTypeTcp:
-		ret.transporter = newTcpTransport(addr)
+		t := newTcpTransport(addr)
+		o.applyTransporter(&t.baseTransporter)
+		ret.transporter = t
 	case TransTypeUdp:
-		ret.transporter = newUdpTransport(addr)
+		t := newUdpTransport(addr)
+		o.applyUdpTransporter(t)
+		ret.transporter = t
 	default:
 		panic("unknown transporter type")
 	}
@@ -140,21 +187,53 @@ func NewFins(plcType PlcType, transType TransType, addr string) Fins {
 	return ret
 }
 
+func (f *fins) newHeader(requireResp bool, sid byte) *finsHeader {
+	header := newFinsHeader(DataClassCommand, requireResp, sid)
+	f.options.applyHeader(header)
+	if f.dst != nil {
+		f.dst.applyDestination(header)
+	}
+	return header
+}
+
+// Route returns a Fins sending all its requests to dst over the connection of f,
+// Open and Close of the returned Fins act on that shared connection.
+func (f *fins) Route(dst NodeAddress) (Fins, error) {
+	if err := dst.validateDestination(); err != nil {
+		return nil, err
+	}
+
+	return &fins{
+		InnerLog:    f.InnerLog,
+		plcType:     f.plcType,
+		transporter: f.transporter,
+		options:     f.options,
+		dst:         &dst,
+		mux:         f.mux,
+	}, nil
+}
+
 func (f *fins) Open() error {
+	return f.OpenContext(context.Background())
+}
+
+func (f *fins) OpenContext(ctx context.Context) error {
 	if f.transporter != nil {
-		return f.transporter.Open()
+		return f.transporter.Open(ctx)
 	}
 
 	return nil
 }
 
 func (f *fins) Close() error {
-	if f.transporter != nil {
-		defer func() {
-			f.transporter = nil
-		}()
+	// the requests read transporter under pendingLock, see getTransporter
+	f.pendingLock.Lock()
+	transporter := f.transporter
+	f.transporter = nil
+	f.pendingLock.Unlock()
 
-		return f.transporter.Close()
+	if transporter != nil {
+		return transporter.Close()
 	}
 
 	return nil
@@ -166,60 +245,229 @@ func (f *fins) SetStateChangeCallback(callback func(oldState, newState State)) {
 	}
 }
 
-func (f *fins) Read(address *FinAddress, length uint16) ([]*FinValue, error) {
-	if length == 0 {
-		return nil, errors.New("fins: Read called with zero length")
+func (f *fins) SetCpuErrorCallback(callback func(err *FinsError)) {
+	f.cpuErrorCallback = callback
+}
+
+// getTransporter returns the transporter of the requests, or ErrClosed once f is closed.
+func (f *fins) getTransporter() (Transporter, error) {
+	f.pendingLock.Lock()
+	defer f.pendingLock.Unlock()
+
+	if f.transporter == nil {
+		return nil, ErrClosed
 	}
 
-	reqHeader := newFinsHeader(DataClassCommand, true, byte(f.sid.Add(1)))
+	return f.transporter, nil
+}
+
+func (f *fins) send(ctx context.Context, reqHeader *finsHeader, cmd Command, params []byte) error {
+	transporter, err := f.getTransporter()
+	if err != nil {
+		return err
+	}
 
 	req := &bytes.Buffer{}
-	_ = req.WriteByte(CommandMemoryRead.Mr())
-	_ = req.WriteByte(CommandMemoryRead.Sr())
+	_ = req.WriteByte(cmd.Mr())
+	_ = req.WriteByte(cmd.Sr())
+	_, _ = req.Write(params)
+
+	f.writeLock.Lock()
+	_, err = transporter.Write(ctx, reqHeader, req.Bytes())
+	f.writeLock.Unlock()
 
-	addr, err := f.plcType.EncodeAddress(address)
 	if err != nil {
-		f.L.Warnf("failed to encode address: %v", err)
+		f.L.Warnf("write to transporter failed: %v", err)
+	}
+
+	return err
+}
+
+// register allocates a SID not used by any in-flight request and starts the reader if needed.
+func (f *fins) register() (byte, chan *response, error) {
+	f.pendingLock.Lock()
+	defer f.pendingLock.Unlock()
+
+	if f.transporter == nil {
+		return 0, nil, ErrClosed
+	}
+
+	if f.pending == nil {
+		f.pending = map[byte]chan *response{}
+	}
+
+	for i := 0; i < 256; i++ {
+		sid := byte(f.sid.Add(1))
+		if _, ok := f.pending[sid]; ok {
+			continue
+		}
+
+		ch := make(chan *response, 1)
+		f.pending[sid] = ch
+
+		if !f.reading {
+			f.reading = true
+			go f.readLoop(f.transporter)
+		}
+
+		return sid, ch, nil
+	}
+
+	return 0, nil, errors.New("too many requests in flight")
+}
+
+func (f *fins) unregister(sid byte, ch chan *response) {
+	f.pendingLock.Lock()
+	defer f.pendingLock.Unlock()
+
+	if f.pending[sid] == ch {
+		delete(f.pending, sid)
+	}
+}
+
+// readLoop reads the frames of the transporter and dispatches them by SID,
+// it exits on the first read error after failing all the pending requests.
+func (f *fins) readLoop(transporter Transporter) {
+	for {
+		header, data, err := transporter.ReadFrame()
+		if err != nil {
+			f.L.Warnf("read frame from transporter failed: %v", err)
+
+			f.pendingLock.Lock()
+			for sid, ch := range f.pending {
+				ch <- &response{err: err}
+				delete(f.pending, sid)
+			}
+			f.reading = false
+			f.pendingLock.Unlock()
+
+			return
+		}
+
+		f.pendingLock.Lock()
+		ch, ok := f.pending[header.SID]
+		delete(f.pending, header.SID)
+		f.pendingLock.Unlock()
+
+		if !ok {
+			f.L.Warnf("drop response of unknown sid %d", header.SID)
+			continue
+		}
+
+		f.timeouts.Store(0)
+		ch <- &response{header: header, data: data}
+	}
+}
+
+func (f *fins) request(ctx context.Context, cmd Command, params []byte) (*response, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	_, _ = req.Write(addr[:])
-	_ = binary.Write(req, binary.BigEndian, length)
+	transporter, err := f.getTransporter()
+	if err != nil {
+		return nil, err
+	}
 
-	_, err = f.transporter.Write(reqHeader, req.Bytes())
+	sid, ch, err := f.register()
 	if err != nil {
-		f.L.Warnf("write to transporter failed: %v", err)
 		return nil, err
 	}
+	defer f.unregister(sid, ch)
 
-	// read resp
-	respHeader, err := f.transporter.ReadHeader()
+	err = f.send(ctx, f.newHeader(true, sid), cmd, params)
 	if err != nil {
-		f.L.Warnf("read header from transporter failed: %v", err)
 		return nil, err
 	}
 
-	if reqHeader.SID != respHeader.SID {
-		f.transporter.setState(StateDisconnected, errors.New("sid not equals"))
-		f.L.Error("req sid not equal to resp sid, reconnect to remote")
-		return nil, fmt.Errorf("expected sid %v but got %v", respHeader.SID, reqHeader.SID)
+	timer := time.NewTimer(transporter.responseTimeout())
+	defer timer.Stop()
+
+	select {
+	case resp := <-ch:
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		return resp, nil
+	case <-timer.C:
+		err = fmt.Errorf("wait response of sid %d timeout", sid)
+		if f.timeouts.Add(1) >= maxResponseTimeouts && transporter.State() == StateConnected {
+			f.timeouts.Store(0)
+			transporter.setState(StateDisconnected, err)
+		}
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait response of sid %d: %w", sid, ctx.Err())
+	}
+}
+
+// executeContext sends the command and returns the response data, which is at least respSize bytes.
+// When the end code is an error the data is returned along with the *FinsError.
+func (f *fins) executeContext(ctx context.Context, cmd Command, params []byte, respSize int) ([]byte, error) {
+	resp, err := f.request(ctx, cmd, params)
+	if err != nil {
+		return nil, err
 	}
 
-	if respHeader.CommandCode[0] != CommandMemoryRead.Mr() || respHeader.CommandCode[1] != CommandMemoryRead.Sr() {
+	respHeader := resp.header
+	if respHeader.CommandCode[0] != cmd.Mr() || respHeader.CommandCode[1] != cmd.Sr() {
 		return nil, fmt.Errorf("invalid command: %x: %x", respHeader.CommandCode[0], respHeader.CommandCode[1])
 	}
 
 	err = respHeader.EndCode.Error()
 	if err != nil {
+		finsErr := err.(*FinsError)
+		finsErr.CommandCode = respHeader.CommandCode
+		finsErr.SID = respHeader.SID
 		f.L.Warnf("end code failed: %v", err)
+		return resp.data, err
+	}
+
+	if respHeader.EndCode.FatalCpuUnitError() || respHeader.EndCode.NonFatalCpuUnitError() {
+		cpuErr := &FinsError{EndCode: respHeader.EndCode, CommandCode: respHeader.CommandCode, SID: respHeader.SID}
+		f.L.Debugf("cpu unit error reported: %v", cpuErr)
+		if f.cpuErrorCallback != nil {
+			f.cpuErrorCallback(cpuErr)
+		}
+	}
+
+	if err = checkSize(resp.data, respSize); err != nil {
 		return nil, err
 	}
 
+	return resp.data, nil
+}
+
+func checkSize(data []byte, size int) error {
+	if len(data) < size {
+		return fmt.Errorf("response too short, expected %d bytes but got %d", size, len(data))
+	}
+
+	return nil
+}
+
+func (f *fins) Read(address *FinAddress, length uint16) ([]*FinValue, error) {
+	return f.ReadContext(context.Background(), address, length)
+}
+
+func (f *fins) ReadContext(ctx context.Context, address *FinAddress, length uint16) ([]*FinValue, error) {
+	if length == 0 {
+		return nil, errors.New("fins: Read called with zero length")
+	}
+
+	addr, err := f.plcType.EncodeAddress(address)
+	if err != nil {
+		f.L.Warnf("failed to encode address: %v", err)
+		return nil, err
+	}
+
+	req := &bytes.Buffer{}
+	_, _ = req.Write(addr[:])
+	_ = binary.Write(req, binary.BigEndian, length)
+
 	itemSize := address.AreaCode.Size()
-	resp := make([]byte, itemSize*int(length))
-	_, err = f.transporter.ReadData(resp)
+	resp, err := f.executeContext(ctx, CommandMemoryRead, req.Bytes(), itemSize*int(length))
 	if err != nil {
-		f.L.Warnf("read data from transporter failed: %v", err)
 		return nil, err
 	}
 
@@ -237,22 +485,21 @@ func (f *fins) Read(address *FinAddress, length uint16) ([]*FinValue, error) {
 }
 
 func (f *fins) Write(address *FinAddress, values []*FinValue) error {
+	return f.WriteContext(context.Background(), address, values)
+}
+
+func (f *fins) WriteContext(ctx context.Context, address *FinAddress, values []*FinValue) error {
 	if len(values) == 0 {
 		return errors.New("no values to write")
 	}
 
-	reqHeader := newFinsHeader(DataClassCommand, true, byte(f.sid.Add(1)))
-
-	req := &bytes.Buffer{}
-	_ = req.WriteByte(CommandMemoryWrite.Mr())
-	_ = req.WriteByte(CommandMemoryWrite.Sr())
-
 	addr, err := f.plcType.EncodeAddress(address)
 	if err != nil {
 		f.L.Warnf("failed to encode address: %v", err)
 		return err
 	}
 
+	req := &bytes.Buffer{}
 	_, _ = req.Write(addr[:])
 	_ = binary.Write(req, binary.BigEndian, uint16(len(values)))
 
@@ -260,47 +507,90 @@ func (f *fins) Write(address *FinAddress, values []*FinValue) error {
 		req.Write(value.Buf)
 	}
 
-	_, err = f.transporter.Write(reqHeader, req.Bytes())
-	if err != nil {
-		f.L.Warnf("write to transporter failed: %v", err)
+	_, err = f.executeContext(ctx, CommandMemoryWrite, req.Bytes(), 0)
+	return err
+}
+
+func (f *fins) Fill(address *FinAddress, count uint16, value *FinValue) error {
+	return f.FillContext(context.Background(), address, count, value)
+}
+
+func (f *fins) FillContext(ctx context.Context, address *FinAddress, count uint16, value *FinValue) error {
+	if count == 0 {
+		return errors.New("fins: Fill called with zero count")
+	}
+
+	if address.AreaCode.Size() != 2 {
+		return fmt.Errorf("memory area %s is not word addressable", address.AreaCode)
+	}
+
+	if value == nil || len(value.Buf) != 2 {
+		return errors.New("fill value must be one word")
+	}
+
+	if err := f.plcType.CheckRange(address, count); err != nil {
 		return err
 	}
 
-	// read resp
-	respHeader, err := f.transporter.ReadHeader()
+	addr, err := f.plcType.EncodeAddress(address)
 	if err != nil {
-		f.L.Warnf("read from transporter failed: %v", err)
+		f.L.Warnf("failed to encode address: %v", err)
 		return err
 	}
 
-	if reqHeader.SID != respHeader.SID {
-		f.transporter.setState(StateDisconnected, errors.New("sid not equals"))
-		f.L.Error("req sid not equal to resp sid, reconnect to remote")
-		return fmt.Errorf("expected sid %v but got %v", respHeader.SID, reqHeader.SID)
-	}
+	req := &bytes.Buffer{}
+	_, _ = req.Write(addr[:])
+	_ = binary.Write(req, binary.BigEndian, count)
+	_, _ = req.Write(value.Buf)
+
+	_, err = f.executeContext(ctx, CommandMemoryFill, req.Bytes(), 0)
+	return err
+}
 
-	if respHeader.CommandCode[0] != CommandMemoryWrite.Mr() || respHeader.CommandCode[1] != CommandMemoryWrite.Sr() {
-		return fmt.Errorf("invalid command: %x: %x", respHeader.CommandCode[0], respHeader.CommandCode[1])
+func (f *fins) Transfer(src, dst *FinAddress, count uint16) error {
+	return f.TransferContext(context.Background(), src, dst, count)
+}
+
+func (f *fins) TransferContext(ctx context.Context, src, dst *FinAddress, count uint16) error {
+	if count == 0 {
+		return errors.New("fins: Transfer called with zero count")
 	}
 
-	err = respHeader.EndCode.Error()
-	if err != nil {
-		f.L.Warnf("end code failed: %v", err)
+	req := &bytes.Buffer{}
+	for _, address := range []*FinAddress{src, dst} {
+		if address.AreaCode.Size() != 2 {
+			return fmt.Errorf("memory area %s is not word addressable", address.AreaCode)
+		}
+
+		if err := f.plcType.CheckRange(address, count); err != nil {
+			return err
+		}
+
+		addr, err := f.plcType.EncodeAddress(address)
+		if err != nil {
+			f.L.Warnf("failed to encode address: %v", err)
+			return err
+		}
+
+		_, _ = req.Write(addr[:])
 	}
 
+	_ = binary.Write(req, binary.BigEndian, count)
+
+	_, err := f.executeContext(ctx, CommandMemoryTransfer, req.Bytes(), 0)
 	return err
 }
 
 func (f *fins) RandomRead(addresses []*FinAddress) ([]*FinValue, error) {
+	return f.RandomReadContext(context.Background(), addresses)
+}
+
+func (f *fins) RandomReadContext(ctx context.Context, addresses []*FinAddress) ([]*FinValue, error) {
 	if len(addresses) == 0 {
 		return nil, errors.New("no addresses to read")
 	}
 
-	reqHeader := newFinsHeader(DataClassCommand, true, byte(f.sid.Add(1)))
-
 	req := &bytes.Buffer{}
-	_ = req.WriteByte(CommandMultipleMemoryRead.Mr())
-	_ = req.WriteByte(CommandMultipleMemoryRead.Sr())
 
 	itemsSize := 0
 
@@ -316,39 +606,8 @@ func (f *fins) RandomRead(addresses []*FinAddress) ([]*FinValue, error) {
 		req.Write(addr[:])
 	}
 
-	_, err := f.transporter.Write(reqHeader, req.Bytes())
-	if err != nil {
-		f.L.Warnf("write to transporter failed: %v", err)
-		return nil, err
-	}
-
-	// read resp
-	respHeader, err := f.transporter.ReadHeader()
-	if err != nil {
-		f.L.Warnf("read from transporter failed: %v", err)
-		return nil, err
-	}
-
-	if reqHeader.SID != respHeader.SID {
-		f.transporter.setState(StateDisconnected, errors.New("sid not equals"))
-		f.L.Error("req sid not equal to resp sid, reconnect to remote")
-		return nil, fmt.Errorf("expected sid %v but got %v", respHeader.SID, reqHeader.SID)
-	}
-
-	if respHeader.CommandCode[0] != CommandMultipleMemoryRead.Mr() || respHeader.CommandCode[1] != CommandMultipleMemoryRead.Sr() {
-		return nil, fmt.Errorf("invalid command: %x: %x", respHeader.CommandCode[0], respHeader.CommandCode[1])
-	}
-
-	err = respHeader.EndCode.Error()
-	if err != nil {
-		f.L.Warnf("end code failed: %v", err)
-		return nil, err
-	}
-
-	resp := make([]byte, itemsSize+len(addresses))
-	_, err = f.transporter.ReadData(resp)
+	resp, err := f.executeContext(ctx, CommandMultipleMemoryRead, req.Bytes(), itemsSize+len(addresses))
 	if err != nil {
-		f.L.Warnf("read data from transporter failed: %v", err)
 		return nil, err
 	}
 
@@ -0,0 +1,48 @@
+package fins
+
+import (
+	"context"
+	"errors"
+)
+
+const accessRightHolderSize = 3
+
+// AcquireAccessRight acquires the access right, an *AccessRightError is returned
+// when another node holds it.
+func (f *fins) AcquireAccessRight() error {
+	return f.AcquireAccessRightContext(context.Background())
+}
+
+func (f *fins) AcquireAccessRightContext(ctx context.Context) error {
+	resp, err := f.executeContext(ctx, CommandAccessRightAcquire, allPrograms, 0)
+
+	var finsErr *FinsError
+	if !errors.As(err, &finsErr) || finsErr.MainCode() != MCAccessRightError {
+		return err
+	}
+
+	// the node holding the access right follows the end code
+	if checkSize(resp, accessRightHolderSize) != nil {
+		return err
+	}
+
+	return &AccessRightError{FinsError: finsErr, Network: resp[0], Node: resp[1], Unit: resp[2]}
+}
+
+func (f *fins) ForceAcquireAccessRight() error {
+	return f.ForceAcquireAccessRightContext(context.Background())
+}
+
+func (f *fins) ForceAcquireAccessRightContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandAccessRightForcedAcquire, allPrograms, 0)
+	return err
+}
+
+func (f *fins) ReleaseAccessRight() error {
+	return f.ReleaseAccessRightContext(context.Background())
+}
+
+func (f *fins) ReleaseAccessRightContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandAccessRightRelease, allPrograms, 0)
+	return err
+}
@@ -0,0 +1,81 @@
+package fins
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const clockSize = 7
+
+// ReadClock reads the PLC clock, the PLC keeps no time zone so the result is in time.Local.
+func (f *fins) ReadClock() (time.Time, error) {
+	return f.ReadClockContext(context.Background())
+}
+
+func (f *fins) ReadClockContext(ctx context.Context) (time.Time, error) {
+	resp, err := f.executeContext(ctx, CommandClockRead, nil, clockSize)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return decodeClock(resp)
+}
+
+// WriteClock sets the PLC clock to the wall clock of t in its own location,
+// the PLC stores a two digit year so t must be in 1970 to 2069.
+func (f *fins) WriteClock(t time.Time) error {
+	return f.WriteClockContext(context.Background(), t)
+}
+
+func (f *fins) WriteClockContext(ctx context.Context, t time.Time) error {
+	if t.Year() < 1970 || t.Year() > 2069 {
+		return fmt.Errorf("year %d out of range 1970 to 2069", t.Year())
+	}
+
+	_, err := f.executeContext(ctx, CommandClockWrite, encodeClock(t), 0)
+	return err
+}
+
+func encodeClock(t time.Time) []byte {
+	return []byte{
+		toBcd(byte(t.Year() % 100)),
+		toBcd(byte(t.Month())),
+		toBcd(byte(t.Day())),
+		toBcd(byte(t.Hour())),
+		toBcd(byte(t.Minute())),
+		toBcd(byte(t.Second())),
+		toBcd(byte(t.Weekday())),
+	}
+}
+
+func decodeClock(buf []byte) (time.Time, error) {
+	var fields [6]int
+	for i := range fields {
+		v, err := fromBcd(buf[i])
+		if err != nil {
+			return time.Time{}, err
+		}
+		fields[i] = int(v)
+	}
+
+	// year 70 to 99 means 1970 to 1999, 00 to 69 means 2000 to 2069
+	year := fields[0] + 2000
+	if fields[0] >= 70 {
+		year = fields[0] + 1900
+	}
+
+	return time.Date(year, time.Month(fields[1]), fields[2], fields[3], fields[4], fields[5], 0, time.Local), nil
+}
+
+func toBcd(v byte) byte {
+	return (v/10)<<4 | v%10
+}
+
+func fromBcd(b byte) (byte, error) {
+	if b>>4 > 9 || b&0x0F > 9 {
+		return 0, fmt.Errorf("invalid bcd value 0x%02x", b)
+	}
+
+	return (b>>4)*10 + b&0x0F, nil
+}
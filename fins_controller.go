@@ -0,0 +1,248 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// allPrograms is the program number used by RUN and STOP, it is fixed to FFFF.
+var allPrograms = []byte{0xFF, 0xFF}
+
+const (
+	controllerDataModelSize = 92
+	controllerDataUnitSize  = 67
+)
+
+type ControllerData struct {
+	Model   string
+	Version string
+	// ProgramAreaSize is the size of the user program area in K words
+	ProgramAreaSize uint16
+	// IOMSize is the size of the bit area in K bytes
+	IOMSize  byte
+	DMWords  uint16
+	TCSize   byte
+	EMBanks  byte
+	CardType byte
+	// CardSize is the memory card size in K bytes
+	CardSize uint16
+	// CpuBusUnits holds the model code of the CPU Bus Unit mounted on each unit number
+	CpuBusUnits []uint16
+	RemoteIO    uint16
+	CpuUnitInfo byte
+}
+
+// PlcType guesses the PlcType from the controller model, ok is false when the model is unknown.
+func (cd *ControllerData) PlcType() (pt PlcType, ok bool) {
+	model := strings.ToUpper(cd.Model)
+	for _, prefix := range []string{"CS", "CJ", "CP", "NSJ"} {
+		if strings.HasPrefix(model, prefix) {
+			return PlcTypeNew, true
+		}
+	}
+
+	if strings.HasPrefix(model, "CV") {
+		return PlcTypeOld, true
+	}
+
+	return pt, false
+}
+
+// FatalError is the fatal error data returned by CONTROLLER STATUS READ (CS/CJ-series layout).
+type FatalError uint16
+
+const (
+	FatalErrorFALS            FatalError = 1 << 6
+	FatalErrorCycleTimeOver   FatalError = 1 << 8
+	FatalErrorProgram         FatalError = 1 << 9
+	FatalErrorIOSetting       FatalError = 1 << 10
+	FatalErrorIOPointOverflow FatalError = 1 << 11
+	FatalErrorInnerBoard      FatalError = 1 << 12
+	FatalErrorDuplication     FatalError = 1 << 13
+	FatalErrorIOBus           FatalError = 1 << 14
+	FatalErrorMemory          FatalError = 1 << 15
+)
+
+func (e FatalError) Has(flag FatalError) bool {
+	return e&flag != 0
+}
+
+// NonFatalError is the non-fatal error data returned by CONTROLLER STATUS READ (CS/CJ-series layout).
+type NonFatalError uint16
+
+const (
+	NonFatalErrorSpecialIOUnitSetting NonFatalError = 1 << 2
+	NonFatalErrorCpuBusUnitSetting    NonFatalError = 1 << 3
+	NonFatalErrorBattery              NonFatalError = 1 << 4
+	NonFatalErrorCpuBusUnit           NonFatalError = 1 << 6
+	NonFatalErrorSpecialIOUnit        NonFatalError = 1 << 7
+	NonFatalErrorIOVerification       NonFatalError = 1 << 9
+	NonFatalErrorPlcSetup             NonFatalError = 1 << 10
+	NonFatalErrorBasicIOUnit          NonFatalError = 1 << 12
+	NonFatalErrorInterruptTask        NonFatalError = 1 << 13
+	NonFatalErrorFAL                  NonFatalError = 1 << 15
+)
+
+func (e NonFatalError) Has(flag NonFatalError) bool {
+	return e&flag != 0
+}
+
+func (f *fins) ControllerData() (*ControllerData, error) {
+	return f.ControllerDataContext(context.Background())
+}
+
+func (f *fins) ControllerDataContext(ctx context.Context) (*ControllerData, error) {
+	resp, err := f.executeContext(ctx, CommandControllerDataRead, []byte{0x00}, controllerDataModelSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cd := &ControllerData{
+		Model:           trimAscii(resp[0:20]),
+		Version:         trimAscii(resp[20:40]),
+		ProgramAreaSize: binary.BigEndian.Uint16(resp[80:82]),
+		IOMSize:         resp[82],
+		DMWords:         binary.BigEndian.Uint16(resp[83:85]),
+		TCSize:          resp[85],
+		EMBanks:         resp[86],
+		CardType:        resp[89],
+		CardSize:        binary.BigEndian.Uint16(resp[90:92]),
+	}
+
+	resp, err = f.executeContext(ctx, CommandControllerDataRead, []byte{0x01}, controllerDataUnitSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cd.CpuBusUnits = make([]uint16, 32)
+	for i := range cd.CpuBusUnits {
+		cd.CpuBusUnits[i] = binary.BigEndian.Uint16(resp[i*2 : i*2+2])
+	}
+	cd.RemoteIO = binary.BigEndian.Uint16(resp[64:66])
+	cd.CpuUnitInfo = resp[66]
+
+	if pt, ok := cd.PlcType(); ok && pt != f.plcType {
+		f.L.Warnf("controller model %s is %s, but fins is configured as %s", cd.Model, pt.Description(), f.plcType.Description())
+	}
+
+	return cd, nil
+}
+
+const controllerStatusSize = 26
+
+type ControllerStatus struct {
+	Status        CpuStatus
+	Mode          OperatingMode
+	FatalError    FatalError
+	NonFatalError NonFatalError
+	// Messages bit 0 to 7 are set when MSG 0 to 7 exist
+	Messages     uint16
+	FalNo        uint16
+	ErrorMessage string
+}
+
+// HasMessage reports whether message number no (0 to 7) exists.
+func (cs *ControllerStatus) HasMessage(no int) bool {
+	return no >= 0 && no < 8 && cs.Messages&(1<<no) != 0
+}
+
+func (f *fins) ControllerStatus() (*ControllerStatus, error) {
+	return f.ControllerStatusContext(context.Background())
+}
+
+func (f *fins) ControllerStatusContext(ctx context.Context) (*ControllerStatus, error) {
+	resp, err := f.executeContext(ctx, CommandControllerStatusRead, nil, controllerStatusSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ControllerStatus{
+		Status:        CpuStatus(resp[0]),
+		Mode:          OperatingMode(resp[1]),
+		FatalError:    FatalError(binary.BigEndian.Uint16(resp[2:4])),
+		NonFatalError: NonFatalError(binary.BigEndian.Uint16(resp[4:6])),
+		Messages:      binary.BigEndian.Uint16(resp[6:8]),
+		FalNo:         binary.BigEndian.Uint16(resp[8:10]),
+		ErrorMessage:  trimAscii(resp[10:26]),
+	}, nil
+}
+
+// trimAscii converts a fixed-size ASCII field to a string without padding.
+func trimAscii(buf []byte) string {
+	return strings.TrimRight(string(buf), " \x00")
+}
+
+func (f *fins) Run(mode OperatingMode) error {
+	return f.RunContext(context.Background(), mode)
+}
+
+func (f *fins) RunContext(ctx context.Context, mode OperatingMode) error {
+	if mode != OperatingModeMonitor && mode != OperatingModeRun {
+		return fmt.Errorf("run only supports %s or %s mode, got %s", OperatingModeMonitor, OperatingModeRun, mode)
+	}
+
+	_, err := f.executeContext(ctx, CommandRun, append(allPrograms[:2:2], mode.Val()), 0)
+	return modeChangeError(mode, err)
+}
+
+func (f *fins) Stop() error {
+	return f.StopContext(context.Background())
+}
+
+func (f *fins) StopContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandStop, allPrograms, 0)
+	return modeChangeError(OperatingModeProgram, err)
+}
+
+func modeChangeError(mode OperatingMode, err error) error {
+	if errors.Is(err, NotExecutableInCurrentModeError) || errors.Is(err, CannotStartStopError) {
+		return fmt.Errorf("change to %s mode refused: %w", mode, err)
+	}
+
+	return err
+}
+
+const (
+	cycleTimeInit = 0x00
+	cycleTimeRead = 0x01
+	cycleTimeSize = 12
+	// cycleTimeUnit is the unit of the cycle time values
+	cycleTimeUnit = 100 * time.Microsecond
+)
+
+type CycleTime struct {
+	Average time.Duration
+	Max     time.Duration
+	Min     time.Duration
+}
+
+func (f *fins) CycleTime() (*CycleTime, error) {
+	return f.CycleTimeContext(context.Background())
+}
+
+func (f *fins) CycleTimeContext(ctx context.Context) (*CycleTime, error) {
+	resp, err := f.executeContext(ctx, CommandCycleTimeRead, []byte{cycleTimeRead}, cycleTimeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CycleTime{
+		Average: time.Duration(binary.BigEndian.Uint32(resp[0:4])) * cycleTimeUnit,
+		Max:     time.Duration(binary.BigEndian.Uint32(resp[4:8])) * cycleTimeUnit,
+		Min:     time.Duration(binary.BigEndian.Uint32(resp[8:12])) * cycleTimeUnit,
+	}, nil
+}
+
+// InitCycleTime resets the average, max and min cycle time.
+func (f *fins) InitCycleTime() error {
+	return f.InitCycleTimeContext(context.Background())
+}
+
+func (f *fins) InitCycleTimeContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandCycleTimeRead, []byte{cycleTimeInit}, 0)
+	return err
+}
@@ -0,0 +1,75 @@
+package fins
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	// LoopbackMaxSize is the max payload of a loopback test
+	LoopbackMaxSize = 1998
+	// BroadcastMaxSize is the max payload of a broadcast test
+	BroadcastMaxSize = 2000
+
+	broadcastNode = 0xFF
+	broadcastUnit = 0xFE
+)
+
+// Loopback sends payload to the PLC and checks that it is echoed back,
+// the round trip time is returned on success.
+func (f *fins) Loopback(payload []byte) (time.Duration, error) {
+	return f.LoopbackContext(context.Background(), payload)
+}
+
+func (f *fins) LoopbackContext(ctx context.Context, payload []byte) (time.Duration, error) {
+	if len(payload) > LoopbackMaxSize {
+		return 0, fmt.Errorf("loopback payload longer than %d bytes", LoopbackMaxSize)
+	}
+
+	start := time.Now()
+	resp, err := f.executeContext(ctx, CommandLoopbackTest, payload, len(payload))
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(payload, resp) {
+		return elapsed, fmt.Errorf("loopback data mismatch, sent % x but got % x", payload, resp)
+	}
+
+	return elapsed, nil
+}
+
+// BroadcastTestSend broadcasts payload to all nodes of the local network, no response is returned.
+func (f *fins) BroadcastTestSend(payload []byte) error {
+	return f.BroadcastTestSendContext(context.Background(), payload)
+}
+
+func (f *fins) BroadcastTestSendContext(ctx context.Context, payload []byte) error {
+	if len(payload) > BroadcastMaxSize {
+		return fmt.Errorf("broadcast payload longer than %d bytes", BroadcastMaxSize)
+	}
+
+	header := f.newHeader(false, byte(f.sid.Add(1)))
+	header.DA1 = broadcastNode
+	header.DA2 = broadcastUnit
+
+	return f.send(ctx, header, CommandBroadcastTestDataSend, payload)
+}
+
+// BroadcastTestResults reads the number of broadcast test frames received by the node, the count is reset after reading.
+func (f *fins) BroadcastTestResults() (uint16, error) {
+	return f.BroadcastTestResultsContext(context.Background())
+}
+
+func (f *fins) BroadcastTestResultsContext(ctx context.Context) (uint16, error) {
+	resp, err := f.executeContext(ctx, CommandBroadcastTestResultsRead, nil, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint16(resp), nil
+}
@@ -0,0 +1,169 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	errorLogHeaderSize = 6
+	errorLogRecordSize = 10
+
+	messageSize    = 32
+	falMessageSize = 20
+)
+
+const (
+	// ErrorClearAll clears all current errors
+	ErrorClearAll uint16 = 0xFFFF
+	// ErrorClearCurrent clears the current error with the highest priority
+	ErrorClearCurrent uint16 = 0xFFFE
+)
+
+const (
+	messageRead   uint16 = 0x0000
+	messageClear  uint16 = 0x4000
+	falNumberRead uint16 = 0x8000
+)
+
+type Message struct {
+	No   int
+	Text string
+}
+
+type FALMessage struct {
+	// No is the FAL/FALS number, 0 when there is no FAL/FALS error
+	No      uint16
+	Message string
+}
+
+type ErrorLogRecord struct {
+	Code   uint16
+	Detail uint16
+	Time   time.Time
+}
+
+func (r *ErrorLogRecord) Text() string {
+	return CpuErrorText(r.Code)
+}
+
+func (r *ErrorLogRecord) String() string {
+	return fmt.Sprintf("%s 0x%04x(0x%04x): %s", r.Time.Format(time.DateTime), r.Code, r.Detail, r.Text())
+}
+
+func (f *fins) ClearError(code uint16) error {
+	return f.ClearErrorContext(context.Background(), code)
+}
+
+func (f *fins) ClearErrorContext(ctx context.Context, code uint16) error {
+	_, err := f.executeContext(ctx, CommandErrorClear, binary.BigEndian.AppendUint16(nil, code), 0)
+	return err
+}
+
+// ReadMessages reads the MSG 0 to 7 messages, messages not set are skipped.
+func (f *fins) ReadMessages() ([]*Message, error) {
+	return f.ReadMessagesContext(context.Background())
+}
+
+func (f *fins) ReadMessagesContext(ctx context.Context) ([]*Message, error) {
+	resp, err := f.executeContext(ctx, CommandMessageRead, binary.BigEndian.AppendUint16(nil, messageRead|0x00FF), 2+8*messageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*Message
+	for i := 0; i < 8; i++ {
+		text := trimAscii(resp[2+i*messageSize : 2+(i+1)*messageSize])
+		if len(text) > 0 {
+			messages = append(messages, &Message{No: i, Text: text})
+		}
+	}
+
+	return messages, nil
+}
+
+// ClearMessages clears the messages whose bit is set in mask, bit 0 to 7 are MSG 0 to 7.
+func (f *fins) ClearMessages(mask byte) error {
+	return f.ClearMessagesContext(context.Background(), mask)
+}
+
+func (f *fins) ClearMessagesContext(ctx context.Context, mask byte) error {
+	_, err := f.executeContext(ctx, CommandMessageRead, binary.BigEndian.AppendUint16(nil, messageClear|uint16(mask)), 0)
+	return err
+}
+
+// ReadFALMessages reads the FAL/FALS number and message of the current FAL/FALS error.
+func (f *fins) ReadFALMessages() (*FALMessage, error) {
+	return f.ReadFALMessagesContext(context.Background())
+}
+
+func (f *fins) ReadFALMessagesContext(ctx context.Context) (*FALMessage, error) {
+	resp, err := f.executeContext(ctx, CommandMessageRead, binary.BigEndian.AppendUint16(nil, falNumberRead), falMessageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FALMessage{
+		No:      binary.BigEndian.Uint16(resp[2:4]),
+		Message: trimAscii(resp[4:20]),
+	}, nil
+}
+
+func (f *fins) ReadErrorLog(start, count uint16) ([]*ErrorLogRecord, error) {
+	return f.ReadErrorLogContext(context.Background(), start, count)
+}
+
+func (f *fins) ReadErrorLogContext(ctx context.Context, start, count uint16) ([]*ErrorLogRecord, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("fins: ReadErrorLog called with zero count")
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, start)
+	req = binary.BigEndian.AppendUint16(req, count)
+
+	resp, err := f.executeContext(ctx, CommandErrorLogRead, req, errorLogHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// resp[0:2] max records, resp[2:4] stored records, resp[4:6] records read
+	readCount := int(binary.BigEndian.Uint16(resp[4:6]))
+	if readCount == 0 {
+		return nil, nil
+	}
+
+	resp = resp[errorLogHeaderSize:]
+	if err = checkSize(resp, readCount*errorLogRecordSize); err != nil {
+		return nil, err
+	}
+
+	records := make([]*ErrorLogRecord, readCount)
+	for i := range records {
+		buf := resp[i*errorLogRecordSize : (i+1)*errorLogRecordSize]
+
+		// time is stored as minute, second, day, hour, year, month
+		t, err := decodeClock([]byte{buf[8], buf[9], buf[6], buf[7], buf[4], buf[5]})
+		if err != nil {
+			return nil, err
+		}
+
+		records[i] = &ErrorLogRecord{
+			Code:   binary.BigEndian.Uint16(buf[0:2]),
+			Detail: binary.BigEndian.Uint16(buf[2:4]),
+			Time:   t,
+		}
+	}
+
+	return records, nil
+}
+
+func (f *fins) ClearErrorLog() error {
+	return f.ClearErrorLogContext(context.Background())
+}
+
+func (f *fins) ClearErrorLogContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandErrorLogClear, nil, 0)
+	return err
+}
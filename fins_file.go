@@ -0,0 +1,397 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// FileChunkSize is the max number of bytes per single file read or write
+	FileChunkSize = 990
+
+	fileNamePageSize   = 64
+	fileNameSize       = 12
+	fileNameBaseLength = 8
+	fileNameExtLength  = 3
+	fileEntrySize      = 20
+	diskInfoSize       = 26
+	fileReadSize       = 10
+	maxDirSize         = 65
+	fileLastFlag       = 0x8000
+)
+
+const (
+	fileWriteNew    uint16 = 0x0001
+	fileWriteAppend uint16 = 0x0002
+
+	directoryCreate uint16 = 0x0000
+	directoryDelete uint16 = 0x0001
+)
+
+type DiskInfo struct {
+	VolumeLabel string
+	Time        time.Time
+	Capacity    uint32
+	Free        uint32
+	Files       uint16
+}
+
+type FileEntry struct {
+	Name    string
+	Size    uint32
+	ModTime time.Time
+	// dir is set for the entries known to be directories, such as the root of FileFS
+	dir bool
+}
+
+// IsDir reports whether the entry is a directory. FILE NAME READ returns no attribute,
+// so an entry is taken as a directory when it has no extension and no size, which
+// also matches an empty file without extension.
+func (fe *FileEntry) IsDir() bool {
+	return fe.dir || (fe.Size == 0 && !strings.Contains(fe.Name, "."))
+}
+
+// encodeFileName converts name to the 8.3 format padded with spaces.
+func encodeFileName(name string) ([]byte, error) {
+	base, ext, _ := strings.Cut(name, ".")
+	if len(base) == 0 || len(base) > fileNameBaseLength || len(ext) > fileNameExtLength {
+		return nil, fmt.Errorf("invalid file name %q, must be in 8.3 format", name)
+	}
+
+	ret := []byte(fmt.Sprintf("%-8s.%-3s", base, ext))
+	if len(ext) == 0 {
+		ret[fileNameBaseLength] = ' '
+	}
+
+	return ret, nil
+}
+
+func decodeFileName(buf []byte) string {
+	base := strings.TrimRight(string(buf[:fileNameBaseLength]), " \x00")
+	ext := strings.TrimRight(string(buf[fileNameBaseLength+1:fileNameSize]), " \x00")
+	if len(ext) == 0 {
+		return base
+	}
+
+	return base + "." + ext
+}
+
+// encodeDir converts a slash separated directory to the absolute directory path
+// preceded by its length, the root directory has no path.
+func encodeDir(dir string) ([]byte, error) {
+	dir = strings.Trim(strings.ReplaceAll(dir, "\\", "/"), "/")
+	if dir == "" || dir == "." {
+		return []byte{0x00, 0x00}, nil
+	}
+
+	path := "\\" + strings.ReplaceAll(dir, "/", "\\")
+	if len(path) > maxDirSize {
+		return nil, fmt.Errorf("directory %q longer than %d bytes", dir, maxDirSize)
+	}
+
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(path))), path...), nil
+}
+
+// decodeFileTime decodes the FAT style date and time used by file memory.
+func decodeFileTime(v uint32) time.Time {
+	return time.Date(int(v>>25)+1980, time.Month(v>>21&0x0F), int(v>>16&0x1F),
+		int(v>>11&0x1F), int(v>>5&0x3F), int(v&0x1F)*2, 0, time.Local)
+}
+
+func (f *fins) ListFiles(disk Disk, dir string) (*DiskInfo, []*FileEntry, error) {
+	return f.ListFilesContext(context.Background(), disk, dir)
+}
+
+func (f *fins) ListFilesContext(ctx context.Context, disk Disk, dir string) (*DiskInfo, []*FileEntry, error) {
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info *DiskInfo
+	var entries []*FileEntry
+
+	for {
+		req := binary.BigEndian.AppendUint16(nil, disk.Val())
+		req = binary.BigEndian.AppendUint16(req, uint16(len(entries)))
+		req = binary.BigEndian.AppendUint16(req, fileNamePageSize)
+		req = append(req, dirBuf...)
+
+		resp, err := f.executeContext(ctx, CommandFileNameRead, req, diskInfoSize+2)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if info == nil {
+			info = &DiskInfo{
+				VolumeLabel: trimAscii(resp[0:12]),
+				Time:        decodeFileTime(binary.BigEndian.Uint32(resp[12:16])),
+				Capacity:    binary.BigEndian.Uint32(resp[16:20]),
+				Free:        binary.BigEndian.Uint32(resp[20:24]),
+				Files:       binary.BigEndian.Uint16(resp[24:26]),
+			}
+		}
+
+		count := binary.BigEndian.Uint16(resp[26:28])
+		last := count&fileLastFlag != 0
+		count &^= fileLastFlag
+
+		if count > 0 {
+			resp = resp[diskInfoSize+2:]
+			if err = checkSize(resp, int(count)*fileEntrySize); err != nil {
+				return nil, nil, err
+			}
+
+			for i := 0; i < int(count); i++ {
+				buf := resp[i*fileEntrySize : (i+1)*fileEntrySize]
+				entries = append(entries, &FileEntry{
+					Name:    decodeFileName(buf[0:12]),
+					ModTime: decodeFileTime(binary.BigEndian.Uint32(buf[12:16])),
+					Size:    binary.BigEndian.Uint32(buf[16:20]),
+				})
+			}
+		}
+
+		if last || count == 0 {
+			return info, entries, nil
+		}
+	}
+}
+
+func (f *fins) ReadFile(disk Disk, dir, name string) ([]byte, error) {
+	return f.ReadFileContext(context.Background(), disk, dir, name)
+}
+
+func (f *fins) ReadFileContext(ctx context.Context, disk Disk, dir, name string) ([]byte, error) {
+	nameBuf, err := encodeFileName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	for {
+		req := binary.BigEndian.AppendUint16(nil, disk.Val())
+		req = append(req, nameBuf...)
+		req = binary.BigEndian.AppendUint32(req, uint32(len(data)))
+		req = binary.BigEndian.AppendUint16(req, FileChunkSize)
+		req = append(req, dirBuf...)
+
+		resp, err := f.executeContext(ctx, CommandSingleFileRead, req, fileReadSize)
+		if err != nil {
+			return nil, err
+		}
+
+		// resp[0:4] file capacity, resp[4:8] file position, resp[8:10] data length
+		capacity := binary.BigEndian.Uint32(resp[0:4])
+		length := binary.BigEndian.Uint16(resp[8:10])
+
+		if length > 0 {
+			chunk := resp[fileReadSize:]
+			if err = checkSize(chunk, int(length)); err != nil {
+				return nil, err
+			}
+			data = append(data, chunk[:length]...)
+		}
+
+		if uint32(len(data)) >= capacity {
+			return data, nil
+		}
+
+		if length == 0 {
+			return nil, errors.New("file read returned no data before the end of file")
+		}
+	}
+}
+
+// WriteFile creates or overwrites the file with data.
+func (f *fins) WriteFile(disk Disk, dir, name string, data []byte) error {
+	return f.WriteFileContext(context.Background(), disk, dir, name, data)
+}
+
+func (f *fins) WriteFileContext(ctx context.Context, disk Disk, dir, name string, data []byte) error {
+	nameBuf, err := encodeFileName(name)
+	if err != nil {
+		return err
+	}
+
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return err
+	}
+
+	position := 0
+	for {
+		length := len(data) - position
+		if length > FileChunkSize {
+			length = FileChunkSize
+		}
+
+		code := fileWriteAppend
+		if position == 0 {
+			code = fileWriteNew
+		}
+
+		req := binary.BigEndian.AppendUint16(nil, disk.Val())
+		req = binary.BigEndian.AppendUint16(req, code)
+		req = append(req, nameBuf...)
+		req = binary.BigEndian.AppendUint32(req, uint32(position))
+		req = binary.BigEndian.AppendUint16(req, uint16(length))
+		req = append(req, dirBuf...)
+		req = append(req, data[position:position+length]...)
+
+		if _, err = f.executeContext(ctx, CommandSingleFileWrite, req, 0); err != nil {
+			return err
+		}
+
+		position += length
+		if position >= len(data) {
+			return nil
+		}
+	}
+}
+
+func (f *fins) DeleteFiles(disk Disk, dir string, names ...string) error {
+	return f.DeleteFilesContext(context.Background(), disk, dir, names...)
+}
+
+func (f *fins) DeleteFilesContext(ctx context.Context, disk Disk, dir string, names ...string) error {
+	if len(names) == 0 {
+		return errors.New("no files to delete")
+	}
+
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return err
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, disk.Val())
+	req = binary.BigEndian.AppendUint16(req, uint16(len(names)))
+	req = append(req, dirBuf...)
+
+	for _, name := range names {
+		nameBuf, err := encodeFileName(name)
+		if err != nil {
+			return err
+		}
+		req = append(req, nameBuf...)
+	}
+
+	resp, err := f.executeContext(ctx, CommandFileDelete, req, 2)
+	if err != nil {
+		return err
+	}
+
+	if deleted := binary.BigEndian.Uint16(resp); int(deleted) != len(names) {
+		return fmt.Errorf("deleted %d of %d files", deleted, len(names))
+	}
+
+	return nil
+}
+
+func (f *fins) CopyFile(srcDisk Disk, srcDir, srcName string, dstDisk Disk, dstDir, dstName string) error {
+	return f.CopyFileContext(context.Background(), srcDisk, srcDir, srcName, dstDisk, dstDir, dstName)
+}
+
+func (f *fins) CopyFileContext(ctx context.Context, srcDisk Disk, srcDir, srcName string, dstDisk Disk, dstDir, dstName string) error {
+	var req []byte
+	for _, file := range []struct {
+		disk      Disk
+		dir, name string
+	}{{srcDisk, srcDir, srcName}, {dstDisk, dstDir, dstName}} {
+		dirBuf, err := encodeDir(file.dir)
+		if err != nil {
+			return err
+		}
+
+		nameBuf, err := encodeFileName(file.name)
+		if err != nil {
+			return err
+		}
+
+		req = binary.BigEndian.AppendUint16(req, file.disk.Val())
+		req = append(req, dirBuf...)
+		req = append(req, nameBuf...)
+	}
+
+	_, err := f.executeContext(ctx, CommandFileCopy, req, 0)
+	return err
+}
+
+func (f *fins) RenameFile(disk Disk, dir, oldName, newName string) error {
+	return f.RenameFileContext(context.Background(), disk, dir, oldName, newName)
+}
+
+func (f *fins) RenameFileContext(ctx context.Context, disk Disk, dir, oldName, newName string) error {
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return err
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, disk.Val())
+	req = append(req, dirBuf...)
+
+	for _, name := range []string{oldName, newName} {
+		nameBuf, err := encodeFileName(name)
+		if err != nil {
+			return err
+		}
+		req = append(req, nameBuf...)
+	}
+
+	_, err = f.executeContext(ctx, CommandFileNameChange, req, 0)
+	return err
+}
+
+func (f *fins) CreateDirectory(disk Disk, dir, name string) error {
+	return f.CreateDirectoryContext(context.Background(), disk, dir, name)
+}
+
+func (f *fins) CreateDirectoryContext(ctx context.Context, disk Disk, dir, name string) error {
+	return f.directory(ctx, directoryCreate, disk, dir, name)
+}
+
+func (f *fins) DeleteDirectory(disk Disk, dir, name string) error {
+	return f.DeleteDirectoryContext(context.Background(), disk, dir, name)
+}
+
+func (f *fins) DeleteDirectoryContext(ctx context.Context, disk Disk, dir, name string) error {
+	return f.directory(ctx, directoryDelete, disk, dir, name)
+}
+
+func (f *fins) directory(ctx context.Context, code uint16, disk Disk, dir, name string) error {
+	nameBuf, err := encodeFileName(name)
+	if err != nil {
+		return err
+	}
+
+	dirBuf, err := encodeDir(dir)
+	if err != nil {
+		return err
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, disk.Val())
+	req = binary.BigEndian.AppendUint16(req, code)
+	req = append(req, nameBuf...)
+	req = append(req, dirBuf...)
+
+	_, err = f.executeContext(ctx, CommandDirectoryCreateDelete, req, 0)
+	return err
+}
+
+func (f *fins) FormatFileMemory(disk Disk) error {
+	return f.FormatFileMemoryContext(context.Background(), disk)
+}
+
+func (f *fins) FormatFileMemoryContext(ctx context.Context, disk Disk) error {
+	_, err := f.executeContext(ctx, CommandFileMemoryFormat, binary.BigEndian.AppendUint16(nil, disk.Val()), 0)
+	return err
+}
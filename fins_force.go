@@ -0,0 +1,72 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+type ForceOp struct {
+	Address *FinAddress
+	Action  ForceAction
+}
+
+func (f *fins) ForceSet(address *FinAddress) error {
+	return f.ForceSetContext(context.Background(), address)
+}
+
+func (f *fins) ForceSetContext(ctx context.Context, address *FinAddress) error {
+	return f.ForceContext(ctx, []ForceOp{{Address: address, Action: ForceActionSet}})
+}
+
+func (f *fins) ForceReset(address *FinAddress) error {
+	return f.ForceResetContext(context.Background(), address)
+}
+
+func (f *fins) ForceResetContext(ctx context.Context, address *FinAddress) error {
+	return f.ForceContext(ctx, []ForceOp{{Address: address, Action: ForceActionReset}})
+}
+
+func (f *fins) ForceCancelAll() error {
+	return f.ForceCancelAllContext(context.Background())
+}
+
+func (f *fins) ForceCancelAllContext(ctx context.Context) error {
+	_, err := f.executeContext(ctx, CommandForcedSetResetCancel, nil, 0)
+	return err
+}
+
+func (f *fins) Force(ops []ForceOp) error {
+	return f.ForceContext(context.Background(), ops)
+}
+
+func (f *fins) ForceContext(ctx context.Context, ops []ForceOp) error {
+	if len(ops) == 0 {
+		return errors.New("no bits to force")
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, uint16(len(ops)))
+	for _, op := range ops {
+		if !op.Action.IsValid() {
+			return fmt.Errorf("invalid force action 0x%04x", op.Action.Val())
+		}
+
+		dt := op.Address.AreaCode.DataType()
+		if dt != DataTypeBit.Val() && dt != DataTypeCF.Val() {
+			return fmt.Errorf("memory area %s is not a bit area", op.Address.AreaCode)
+		}
+
+		addr, err := f.plcType.EncodeAddress(op.Address)
+		if err != nil {
+			f.L.Warnf("failed to encode address: %v", err)
+			return err
+		}
+
+		req = binary.BigEndian.AppendUint16(req, op.Action.Val())
+		req = append(req, addr[:]...)
+	}
+
+	_, err := f.executeContext(ctx, CommandForcedSetReset, req, 0)
+	return err
+}
@@ -0,0 +1,144 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+)
+
+const (
+	// maxNetworkNodes is the number of nodes reported by network and data link status,
+	// node addresses are the DA1/SA1 node addresses described in newFinsHeader
+	maxNetworkNodes = 62
+
+	// network status: member nodes, communications cycle time, polling node, cyclic operation,
+	// cyclic transmission status, cyclic non-participation and cyclic non-reception nodes
+	networkMembersSize = maxNetworkNodes / 2
+	networkNodeBits    = 8
+	networkStatusSize  = networkMembersSize + 2 + 3 + networkNodeBits*2
+	dataLinkStatusSize = maxNetworkNodes + 2
+
+	// networkCycleTimeUnit is the unit of the communications cycle time
+	networkCycleTimeUnit = 100 * time.Microsecond
+)
+
+// NetworkNodeStatus is the 4 bits network participation status of a node.
+type NetworkNodeStatus byte
+
+func (s NetworkNodeStatus) Participating() bool {
+	return s&0x01 != 0
+}
+
+type NetworkStatus struct {
+	// Nodes is indexed by node address, index 0 is unused
+	Nodes              []NetworkNodeStatus
+	CycleTime          time.Duration
+	PollingNode        byte
+	CyclicOperation    byte
+	CyclicTransmission byte
+	// CyclicNonParticipation and CyclicNonReception have bit n set for node n
+	CyclicNonParticipation uint64
+	CyclicNonReception     uint64
+}
+
+// ParticipatingNodes returns the addresses of the nodes participating in the network.
+func (ns *NetworkStatus) ParticipatingNodes() (nodes []byte) {
+	for node, status := range ns.Nodes {
+		if status.Participating() {
+			nodes = append(nodes, byte(node))
+		}
+	}
+	return nodes
+}
+
+// DataLinkNodeStatus is the data link status of a node.
+type DataLinkNodeStatus byte
+
+func (s DataLinkNodeStatus) Participating() bool {
+	return s&0x01 != 0
+}
+
+type DataLinkStatus struct {
+	// StatusFlags bit 0 is set when the data links are active
+	StatusFlags byte
+	MasterNode  byte
+	// Nodes is indexed by node address, index 0 is unused
+	Nodes []DataLinkNodeStatus
+}
+
+func (ds *DataLinkStatus) Active() bool {
+	return ds.StatusFlags&0x01 != 0
+}
+
+// ActiveNodes returns the addresses of the nodes participating in the data links.
+func (ds *DataLinkStatus) ActiveNodes() (nodes []byte) {
+	for node, status := range ds.Nodes {
+		if status.Participating() {
+			nodes = append(nodes, byte(node))
+		}
+	}
+	return nodes
+}
+
+func (f *fins) NetworkStatus() (*NetworkStatus, error) {
+	return f.NetworkStatusContext(context.Background())
+}
+
+func (f *fins) NetworkStatusContext(ctx context.Context) (*NetworkStatus, error) {
+	resp, err := f.executeContext(ctx, CommandNetworkStatusRead, nil, networkStatusSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &NetworkStatus{
+		Nodes:                  make([]NetworkNodeStatus, maxNetworkNodes+1),
+		CycleTime:              time.Duration(binary.BigEndian.Uint16(resp[networkMembersSize:])) * networkCycleTimeUnit,
+		PollingNode:            resp[networkMembersSize+2],
+		CyclicOperation:        resp[networkMembersSize+3],
+		CyclicTransmission:     resp[networkMembersSize+4],
+		CyclicNonParticipation: decodeNodeBits(resp[networkMembersSize+5:]),
+		CyclicNonReception:     decodeNodeBits(resp[networkMembersSize+5+networkNodeBits:]),
+	}
+
+	// 4 bits per node, the odd node in the low nibble
+	for node := 1; node <= maxNetworkNodes; node++ {
+		b := resp[(node-1)/2]
+		if node%2 == 0 {
+			b >>= 4
+		}
+		ns.Nodes[node] = NetworkNodeStatus(b & 0x0F)
+	}
+
+	return ns, nil
+}
+
+// decodeNodeBits converts the node bits, node n in bit n%8 of byte n/8, to a mask with bit n set for node n.
+func decodeNodeBits(buf []byte) (mask uint64) {
+	for node := 0; node < networkNodeBits*8; node++ {
+		mask |= uint64(buf[node/8]>>(node%8)&0x01) << node
+	}
+	return mask
+}
+
+func (f *fins) DataLinkStatus() (*DataLinkStatus, error) {
+	return f.DataLinkStatusContext(context.Background())
+}
+
+func (f *fins) DataLinkStatusContext(ctx context.Context) (*DataLinkStatus, error) {
+	resp, err := f.executeContext(ctx, CommandDataLinkStatusRead, nil, dataLinkStatusSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := &DataLinkStatus{
+		StatusFlags: resp[0],
+		MasterNode:  resp[1],
+		Nodes:       make([]DataLinkNodeStatus, maxNetworkNodes+1),
+	}
+
+	for node := 1; node <= maxNetworkNodes; node++ {
+		ds.Nodes[node] = DataLinkNodeStatus(resp[node+1])
+	}
+
+	return ds, nil
+}
@@ -0,0 +1,144 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// parameterChunkWords is the number of words transferred per parameter area command
+	parameterChunkWords = 256
+
+	parameterHeaderSize = 6
+	parameterLastFlag   = 0x8000
+)
+
+func (f *fins) ReadParameterArea(area ParameterArea) ([]byte, error) {
+	return f.ReadParameterAreaContext(context.Background(), area)
+}
+
+func (f *fins) ReadParameterAreaContext(ctx context.Context, area ParameterArea) ([]byte, error) {
+	if !area.IsValid() {
+		return nil, fmt.Errorf("invalid parameter area %d", area)
+	}
+
+	var data []byte
+	for begin := uint16(0); begin < area.Words(); {
+		count := min16(parameterChunkWords, area.Words()-begin)
+
+		chunk, last, err := f.readParameterArea(ctx, area, begin, count)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, chunk...)
+		begin += uint16(len(chunk) / 2)
+
+		if last {
+			break
+		}
+
+		if len(chunk) == 0 {
+			return nil, errors.New("parameter area read returned no data before the last chunk")
+		}
+	}
+
+	return data, nil
+}
+
+func (f *fins) readParameterArea(ctx context.Context, area ParameterArea, begin, count uint16) ([]byte, bool, error) {
+	req := binary.BigEndian.AppendUint16(nil, area.Code())
+	req = binary.BigEndian.AppendUint16(req, begin)
+	req = binary.BigEndian.AppendUint16(req, count)
+
+	resp, err := f.executeContext(ctx, CommandParameterAreaRead, req, parameterHeaderSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// resp[0:2] area code, resp[2:4] beginning word, resp[4:6] number of words with the last flag
+	words := binary.BigEndian.Uint16(resp[4:6])
+	last := words&parameterLastFlag != 0
+	words &^= parameterLastFlag
+
+	if words > count {
+		return nil, false, fmt.Errorf("parameter area read returned %d words, more than %d", words, count)
+	}
+
+	if words == 0 {
+		return nil, last, nil
+	}
+
+	data := resp[parameterHeaderSize:]
+	if err = checkSize(data, int(words)*2); err != nil {
+		return nil, false, err
+	}
+
+	return data[:words*2], last, nil
+}
+
+// WriteParameterArea writes data to the parameter area from its first word,
+// the last flag is set on the final chunk.
+func (f *fins) WriteParameterArea(area ParameterArea, data []byte) error {
+	return f.WriteParameterAreaContext(context.Background(), area, data)
+}
+
+func (f *fins) WriteParameterAreaContext(ctx context.Context, area ParameterArea, data []byte) error {
+	if !area.IsValid() {
+		return fmt.Errorf("invalid parameter area %d", area)
+	}
+
+	if len(data) == 0 || len(data)%2 != 0 || len(data)/2 > int(area.Words()) {
+		return fmt.Errorf("parameter area %s data must be 1 to %d words", area, area.Words())
+	}
+
+	total := uint16(len(data) / 2)
+	for begin := uint16(0); begin < total; {
+		count := min16(parameterChunkWords, total-begin)
+
+		words := count
+		if begin+count == total {
+			words |= parameterLastFlag
+		}
+
+		req := binary.BigEndian.AppendUint16(nil, area.Code())
+		req = binary.BigEndian.AppendUint16(req, begin)
+		req = binary.BigEndian.AppendUint16(req, words)
+		req = append(req, data[begin*2:(begin+count)*2]...)
+
+		if _, err := f.executeContext(ctx, CommandParameterAreaWrite, req, 0); err != nil {
+			return err
+		}
+
+		begin += count
+	}
+
+	return nil
+}
+
+func (f *fins) ClearParameterArea(area ParameterArea) error {
+	return f.ClearParameterAreaContext(context.Background(), area)
+}
+
+func (f *fins) ClearParameterAreaContext(ctx context.Context, area ParameterArea) error {
+	if !area.IsValid() {
+		return fmt.Errorf("invalid parameter area %d", area)
+	}
+
+	req := binary.BigEndian.AppendUint16(nil, area.Code())
+	req = binary.BigEndian.AppendUint16(req, 0)
+	req = binary.BigEndian.AppendUint16(req, area.Words())
+	req = binary.BigEndian.AppendUint16(req, 0)
+
+	_, err := f.executeContext(ctx, CommandParameterAreaClear, req, 0)
+	return err
+}
+
+func min16(a, b uint16) uint16 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,119 @@
+package fins
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// ProgramChunkSize is the max number of bytes per program area read or write
+	ProgramChunkSize = 996
+
+	programHeaderSize = 8
+	programLastFlag   = 0x8000
+)
+
+func (f *fins) ReadProgram(offset uint32, length uint16) (data []byte, last bool, err error) {
+	return f.ReadProgramContext(context.Background(), offset, length)
+}
+
+func (f *fins) ReadProgramContext(ctx context.Context, offset uint32, length uint16) (data []byte, last bool, err error) {
+	if length == 0 || length > ProgramChunkSize {
+		return nil, false, fmt.Errorf("program read length must be between 1 and %d", ProgramChunkSize)
+	}
+
+	req := append([]byte{}, allPrograms...)
+	req = binary.BigEndian.AppendUint32(req, offset)
+	req = binary.BigEndian.AppendUint16(req, length)
+
+	resp, err := f.executeContext(ctx, CommandProgramAreaRead, req, programHeaderSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// resp[0:2] program no, resp[2:6] beginning word, resp[6:8] number of bytes with the last flag
+	size := binary.BigEndian.Uint16(resp[6:8])
+	last = size&programLastFlag != 0
+	size &^= programLastFlag
+
+	if size > length {
+		return nil, false, fmt.Errorf("program read returned %d bytes, more than %d", size, length)
+	}
+
+	if size == 0 {
+		return nil, last, nil
+	}
+
+	data = resp[programHeaderSize:]
+	if err = checkSize(data, int(size)); err != nil {
+		return nil, false, err
+	}
+
+	return data[:size], last, nil
+}
+
+// WriteProgram writes data to the program area, last must be set on the final chunk of a program.
+func (f *fins) WriteProgram(offset uint32, data []byte, last bool) error {
+	return f.WriteProgramContext(context.Background(), offset, data, last)
+}
+
+func (f *fins) WriteProgramContext(ctx context.Context, offset uint32, data []byte, last bool) error {
+	if len(data) == 0 || len(data) > ProgramChunkSize {
+		return fmt.Errorf("program write length must be between 1 and %d", ProgramChunkSize)
+	}
+
+	size := uint16(len(data))
+	if last {
+		size |= programLastFlag
+	}
+
+	req := append([]byte{}, allPrograms...)
+	req = binary.BigEndian.AppendUint32(req, offset)
+	req = binary.BigEndian.AppendUint16(req, size)
+	req = append(req, data...)
+
+	_, err := f.executeContext(ctx, CommandProgramAreaWrite, req, programHeaderSize)
+	return err
+}
+
+func (f *fins) ClearProgram() error {
+	return f.ClearProgramContext(context.Background())
+}
+
+func (f *fins) ClearProgramContext(ctx context.Context) error {
+	// clear code 00 clears the entire program area
+	_, err := f.executeContext(ctx, CommandProgramAreaClear, append(allPrograms[:2:2], 0x00), 0)
+	return err
+}
+
+// BackupProgram reads the whole user program in chunks and writes it to w.
+func BackupProgram(f Fins, w io.Writer) (written int64, err error) {
+	return BackupProgramContext(context.Background(), f, w)
+}
+
+// BackupProgramContext is BackupProgram stopped between chunks when ctx is done.
+func BackupProgramContext(ctx context.Context, f Fins, w io.Writer) (written int64, err error) {
+	for {
+		data, last, err := f.ReadProgramContext(ctx, uint32(written), ProgramChunkSize)
+		if err != nil {
+			return written, err
+		}
+
+		n, err := w.Write(data)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+
+		if last {
+			return written, nil
+		}
+
+		if len(data) == 0 {
+			return written, errors.New("program read returned no data before the last chunk")
+		}
+	}
+}
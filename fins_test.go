@@ -1,10 +1,107 @@
 package fins
 
 import (
+	"bytes"
+	"context"
+	"github.com/expgo/factory"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
+	"time"
 )
 
+type mockTransporter struct {
+	baseTransporter
+	lock   sync.Mutex
+	header *finsHeader
+	req    []byte
+	// reqs are all the requests written in order
+	reqs    [][]byte
+	endCode EndCode
+	// resps are the response data returned to the following requests in order
+	resps  [][]byte
+	frames chan *response
+	// silent drops the requests without response
+	silent bool
+}
+
+func (t *mockTransporter) Open(context.Context) error {
+	return nil
+}
+
+func (t *mockTransporter) State() State {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return State(t.state.Load())
+}
+
+func (t *mockTransporter) setState(state State, _ error) {
+	t.lock.Lock()
+	oldState, callback := State(t.state.Load()), t.callback
+	t.state.Store(int32(state))
+	t.lock.Unlock()
+
+	if callback != nil {
+		callback(oldState, state)
+	}
+}
+
+func (t *mockTransporter) requests() [][]byte {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return append([][]byte(nil), t.reqs...)
+}
+
+func (t *mockTransporter) push(resps ...[]byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.resps = append(t.resps, resps...)
+}
+
+func (t *mockTransporter) Write(_ context.Context, header *finsHeader, data []byte) (int, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.header = header
+	t.req = data
+	t.reqs = append(t.reqs, data)
+
+	// no response is required
+	if header.ICF&0x01 != 0 || t.silent {
+		return len(data), nil
+	}
+
+	var resp []byte
+	if len(t.resps) > 0 {
+		resp, t.resps = t.resps[0], t.resps[1:]
+	}
+
+	respHeader := &respFinsHeader{finsHeader: *header, EndCode: t.endCode}
+	copy(respHeader.CommandCode[:], data[:2])
+	t.frames <- &response{header: respHeader, data: resp}
+
+	return len(data), nil
+}
+
+func (t *mockTransporter) ReadFrame() (*respFinsHeader, []byte, error) {
+	frame := <-t.frames
+	return frame.header, frame.data, nil
+}
+
+func newMockFins(resps ...[]byte) (*fins, *mockTransporter) {
+	mt := &mockTransporter{baseTransporter: baseTransporter{ReadTimeout: time.Second}, resps: resps, frames: make(chan *response, 256)}
+	mt.state.Store(int32(StateConnected))
+	f := factory.New[fins]()
+	f.plcType = PlcTypeNew
+	f.options, _ = newOptions(nil)
+	f.mux = &mux{}
+	f.transporter = mt
+	return f, mt
+}
+
 func TestFinsRead(t *testing.T) {
 	f := NewFins(PlcTypeNew, TransTypeTcp, "0.0.0.0:9600")
 
@@ -112,3 +209,534 @@ func TestFinsRandomReadUdp(t *testing.T) {
 		println(values[1].Uint16())
 	}
 }
+
+func TestFinsFill(t *testing.T) {
+	f, mt := newMockFins()
+
+	addr := &FinAddress{AreaCode: MemoryAreaDMWord, Address: 100}
+	value := &FinValue{FinAddress: addr}
+	_ = value.SetValue(uint16(0x1234))
+
+	err := f.Fill(addr, 10, value)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x03, 0x82, 0x00, 0x64, 0x00, 0x00, 0x0a, 0x12, 0x34}, mt.req)
+
+	err = f.Fill(&FinAddress{AreaCode: MemoryAreaCIOBit, Address: 0}, 1, value)
+	assert.Error(t, err)
+}
+
+func TestFinsTransfer(t *testing.T) {
+	f, mt := newMockFins()
+
+	err := f.Transfer(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, &FinAddress{AreaCode: MemoryAreaHRWord, Address: 10}, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x05, 0x82, 0x00, 0x00, 0x00, 0xb2, 0x00, 0x0a, 0x00, 0x00, 0x04}, mt.req)
+
+	err = f.Transfer(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, &FinAddress{AreaCode: MemoryAreaHRWord, Address: 510}, 4)
+	assert.Error(t, err)
+}
+
+func TestFinsControllerStatus(t *testing.T) {
+	resp := []byte{0x01, 0x04, 0x00, 0x00, 0x80, 0x10, 0x00, 0x05, 0x00, 0x12}
+	resp = append(resp, []byte("BATTERY LOW     ")...)
+	f, mt := newMockFins(resp)
+
+	status, err := f.ControllerStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x01}, mt.req)
+	assert.Equal(t, CpuStatusRun, status.Status)
+	assert.Equal(t, OperatingModeRun, status.Mode)
+	assert.True(t, status.NonFatalError.Has(NonFatalErrorFAL))
+	assert.True(t, status.NonFatalError.Has(NonFatalErrorBattery))
+	assert.False(t, status.FatalError.Has(FatalErrorMemory))
+	assert.True(t, status.HasMessage(2))
+	assert.False(t, status.HasMessage(1))
+	assert.Equal(t, uint16(0x12), status.FalNo)
+	assert.Equal(t, "BATTERY LOW", status.ErrorMessage)
+}
+
+func TestFinsControllerData(t *testing.T) {
+	model := make([]byte, controllerDataModelSize)
+	copy(model, "CJ2M-CPU31          02.01")
+	model[80], model[81] = 0x00, 0x0a
+	model[83], model[84] = 0x80, 0x00
+	model[86] = 0x04
+	unit := make([]byte, controllerDataUnitSize)
+	unit[0] = 0x01
+	f, mt := newMockFins(model, unit)
+
+	cd, err := f.ControllerData()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x05, 0x01, 0x01}, mt.req)
+	assert.Equal(t, "CJ2M-CPU31", cd.Model)
+	assert.Equal(t, "02.01", cd.Version)
+	assert.Equal(t, uint16(10), cd.ProgramAreaSize)
+	assert.Equal(t, uint16(0x8000), cd.DMWords)
+	assert.Equal(t, byte(4), cd.EMBanks)
+	assert.Equal(t, uint16(0x0100), cd.CpuBusUnits[0])
+
+	pt, ok := cd.PlcType()
+	assert.True(t, ok)
+	assert.Equal(t, PlcTypeNew, pt)
+}
+
+func TestFinsRunStop(t *testing.T) {
+	f, mt := newMockFins()
+
+	assert.NoError(t, f.Run(OperatingModeMonitor))
+	assert.Equal(t, []byte{0x04, 0x01, 0xff, 0xff, 0x02}, mt.req)
+
+	assert.Error(t, f.Run(OperatingModeDebug))
+
+	mt.endCode = EndCode{0x22, 0x02}
+	err := f.Stop()
+	assert.ErrorIs(t, err, NotExecutableInCurrentModeError)
+	assert.Equal(t, []byte{0x04, 0x02, 0xff, 0xff}, mt.req)
+}
+
+func TestFinsClock(t *testing.T) {
+	f, mt := newMockFins([]byte{0x24, 0x03, 0x15, 0x13, 0x45, 0x59, 0x05})
+
+	clock, err := f.ReadClock()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x07, 0x01}, mt.req)
+	assert.Equal(t, time.Date(2024, 3, 15, 13, 45, 59, 0, time.Local), clock)
+
+	err = f.WriteClock(time.Date(1999, 12, 31, 23, 59, 58, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x07, 0x02, 0x99, 0x12, 0x31, 0x23, 0x59, 0x58, 0x05}, mt.req)
+
+	assert.Error(t, f.WriteClock(time.Date(1969, 12, 31, 23, 59, 59, 0, time.Local)))
+	assert.Error(t, f.WriteClock(time.Date(2070, 1, 1, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, []byte{0x07, 0x02, 0x99, 0x12, 0x31, 0x23, 0x59, 0x58, 0x05}, mt.req)
+}
+
+func TestFinsErrorLog(t *testing.T) {
+	resp := []byte{0x00, 0x14, 0x00, 0x02, 0x00, 0x02}
+	resp = append(resp, 0x00, 0xf7, 0x00, 0x00, 0x30, 0x15, 0x21, 0x08, 0x24, 0x05)
+	resp = append(resp, 0xc1, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x24, 0x06)
+	f, mt := newMockFins(resp)
+
+	records, err := f.ReadErrorLog(0, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x21, 0x02, 0x00, 0x00, 0x00, 0x14}, mt.req)
+	assert.Len(t, records, 2)
+	assert.Equal(t, uint16(0x00f7), records[0].Code)
+	assert.Equal(t, "Battery error", records[0].Text())
+	assert.Equal(t, time.Date(2024, 5, 21, 8, 30, 15, 0, time.Local), records[0].Time)
+	assert.Equal(t, "FALS 1 executed", records[1].Text())
+
+	assert.NoError(t, f.ClearErrorLog())
+	assert.Equal(t, []byte{0x21, 0x03}, mt.req)
+}
+
+func TestFinsMessages(t *testing.T) {
+	resp := make([]byte, 2+8*messageSize)
+	resp[1] = 0xff
+	for i := 2; i < len(resp); i++ {
+		resp[i] = ' '
+	}
+	copy(resp[2+3*messageSize:], "CHECK HOPPER")
+	f, mt := newMockFins(resp)
+
+	messages, err := f.ReadMessages()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x09, 0x20, 0x00, 0xff}, mt.req)
+	assert.Equal(t, []*Message{{No: 3, Text: "CHECK HOPPER"}}, messages)
+
+	assert.NoError(t, f.ClearMessages(0x08))
+	assert.Equal(t, []byte{0x09, 0x20, 0x40, 0x08}, mt.req)
+
+	mt.push(append([]byte{0x80, 0x00, 0x00, 0x05}, "TANK OVERFLOW   "...))
+	fal, err := f.ReadFALMessages()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x09, 0x20, 0x80, 0x00}, mt.req)
+	assert.Equal(t, &FALMessage{No: 5, Message: "TANK OVERFLOW"}, fal)
+
+	assert.NoError(t, f.ClearError(ErrorClearAll))
+	assert.Equal(t, []byte{0x21, 0x01, 0xff, 0xff}, mt.req)
+}
+
+func TestFinsAccessRight(t *testing.T) {
+	f, mt := newMockFins([]byte{0x00, 0x0a, 0x00})
+	mt.endCode = EndCode{0x30, 0x01}
+
+	err := f.AcquireAccessRight()
+	assert.ErrorIs(t, err, NoAccessRightError)
+	assert.Equal(t, []byte{0x0c, 0x01, 0xff, 0xff}, mt.req)
+
+	var accessErr *AccessRightError
+	assert.ErrorAs(t, err, &accessErr)
+	assert.Equal(t, byte(0x0a), accessErr.Node)
+
+	mt.endCode = EndCode{}
+	assert.NoError(t, f.ForceAcquireAccessRight())
+	assert.Equal(t, []byte{0x0c, 0x02, 0xff, 0xff}, mt.req)
+	assert.NoError(t, f.ReleaseAccessRight())
+	assert.Equal(t, []byte{0x0c, 0x03, 0xff, 0xff}, mt.req)
+}
+
+func TestFinsForce(t *testing.T) {
+	f, mt := newMockFins()
+
+	assert.NoError(t, f.ForceSet(&FinAddress{AreaCode: MemoryAreaCIOBit, Address: 100, Offset: 3}))
+	assert.Equal(t, []byte{0x23, 0x01, 0x00, 0x01, 0x00, 0x01, 0x30, 0x00, 0x64, 0x03}, mt.req)
+
+	err := f.Force([]ForceOp{
+		{Address: &FinAddress{AreaCode: MemoryAreaWRBit, Address: 1, Offset: 15}, Action: ForceActionReset},
+		{Address: &FinAddress{AreaCode: MemoryAreaTIMCF, Address: 2}, Action: ForceActionRelease},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x23, 0x01, 0x00, 0x02, 0x00, 0x00, 0x31, 0x00, 0x01, 0x0f, 0xff, 0xff, 0x09, 0x00, 0x02, 0x00}, mt.req)
+
+	assert.Error(t, f.ForceSet(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}))
+	assert.Error(t, f.ForceSet(&FinAddress{AreaCode: MemoryAreaCIOBit, Address: 0, Offset: 16}))
+
+	assert.NoError(t, f.ForceCancelAll())
+	assert.Equal(t, []byte{0x23, 0x02}, mt.req)
+}
+
+func TestFinValueForced(t *testing.T) {
+	word := &FinValue{FinAddress: &FinAddress{AreaCode: MemoryAreaCIOWordFs}, Buf: []byte{0x00, 0x81, 0x12, 0x34}}
+	value, mask, ok := word.ForcedWord()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x1234), value)
+	assert.Equal(t, uint16(0x0081), mask)
+
+	for _, area := range []MemoryArea{MemoryAreaWRWordFs, MemoryAreaHRWordFs} {
+		word = &FinValue{FinAddress: &FinAddress{AreaCode: area}, Buf: []byte{0x80, 0x00, 0xff, 0x00}}
+		value, mask, ok = word.ForcedWord()
+		assert.True(t, ok)
+		assert.Equal(t, uint16(0xff00), value)
+		assert.Equal(t, uint16(0x8000), mask)
+	}
+
+	_, _, ok = (&FinValue{FinAddress: &FinAddress{AreaCode: MemoryAreaCIOWord}, Buf: []byte{0x12, 0x34}}).ForcedWord()
+	assert.False(t, ok)
+
+	bit := &FinValue{FinAddress: &FinAddress{AreaCode: MemoryAreaTIMCFFs}, Buf: []byte{0x02}}
+	on, forced, ok := bit.ForcedBit()
+	assert.True(t, ok)
+	assert.False(t, on)
+	assert.True(t, forced)
+
+	for _, area := range []MemoryArea{MemoryAreaCIOBitFs, MemoryAreaWRBitFs, MemoryAreaHRBitFs} {
+		bit = &FinValue{FinAddress: &FinAddress{AreaCode: area}, Buf: []byte{0x03}}
+		on, forced, ok = bit.ForcedBit()
+		assert.True(t, ok)
+		assert.True(t, on)
+		assert.True(t, forced)
+	}
+
+	_, _, ok = (&FinValue{FinAddress: &FinAddress{AreaCode: MemoryAreaWRBit}, Buf: []byte{0x03}}).ForcedBit()
+	assert.False(t, ok)
+}
+
+func TestFinsProgram(t *testing.T) {
+	first := append([]byte{0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe4}, bytes.Repeat([]byte{0x11}, ProgramChunkSize)...)
+	last := []byte{0xff, 0xff, 0x00, 0x00, 0x03, 0xe4, 0x80, 0x04, 0x22, 0x22, 0x22, 0x22}
+	f, mt := newMockFins(first, last)
+
+	backup := &bytes.Buffer{}
+	n, err := BackupProgram(f, backup)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(ProgramChunkSize+4), n)
+	assert.Equal(t, []byte{0x03, 0x06, 0xff, 0xff, 0x00, 0x00, 0x03, 0xe4, 0x03, 0xe4}, mt.req)
+	assert.Equal(t, []byte{0x22, 0x22, 0x22, 0x22}, backup.Bytes()[ProgramChunkSize:])
+
+	mt.push([]byte{0xff, 0xff, 0x00, 0x00, 0x00, 0x08, 0x80, 0x02})
+	assert.NoError(t, f.WriteProgram(8, []byte{0x01, 0x02}, true))
+	assert.Equal(t, []byte{0x03, 0x07, 0xff, 0xff, 0x00, 0x00, 0x00, 0x08, 0x80, 0x02, 0x01, 0x02}, mt.req)
+
+	assert.NoError(t, f.ClearProgram())
+	assert.Equal(t, []byte{0x03, 0x08, 0xff, 0xff, 0x00}, mt.req)
+}
+
+func TestFinsParameterArea(t *testing.T) {
+	first := append([]byte{0x80, 0x10, 0x00, 0x00, 0x01, 0x00}, bytes.Repeat([]byte{0x01, 0x02}, 256)...)
+	last := append([]byte{0x80, 0x10, 0x01, 0x00, 0x81, 0x00}, bytes.Repeat([]byte{0x03, 0x04}, 256)...)
+	f, mt := newMockFins(first, last)
+
+	data, err := f.ReadParameterArea(ParameterAreaPlcSetup)
+	assert.NoError(t, err)
+	assert.Len(t, data, 1024)
+	assert.Equal(t, []byte{0x02, 0x01, 0x80, 0x10, 0x01, 0x00, 0x01, 0x00}, mt.req)
+	assert.Equal(t, []byte{0x03, 0x04}, data[512:514])
+
+	err = f.WriteParameterArea(ParameterAreaRoutingTable, []byte{0x00, 0x01, 0x00, 0x02})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x02, 0x80, 0x13, 0x00, 0x00, 0x80, 0x02, 0x00, 0x01, 0x00, 0x02}, mt.req)
+
+	assert.NoError(t, f.ClearParameterArea(ParameterAreaIOTable))
+	assert.Equal(t, []byte{0x02, 0x03, 0x80, 0x12, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00}, mt.req)
+}
+
+func TestFinsFileMemory(t *testing.T) {
+	resp := append([]byte("CARD        "), 0x58, 0x95, 0x60, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x02)
+	resp = append(resp, 0x80, 0x02)
+	resp = append(resp, []byte("RECIPE  .CSV")...)
+	resp = append(resp, 0x58, 0x95, 0x60, 0x00, 0x00, 0x00, 0x00, 0x05)
+	resp = append(resp, []byte("LOGS        ")...)
+	resp = append(resp, 0x58, 0x95, 0x60, 0x00, 0x00, 0x00, 0x00, 0x00)
+	f, mt := newMockFins(resp)
+
+	info, entries, err := f.ListFiles(DiskMemoryCard, "/")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x22, 0x01, 0x80, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00}, mt.req)
+	assert.Equal(t, "CARD", info.VolumeLabel)
+	assert.Equal(t, time.Date(2024, 4, 21, 12, 0, 0, 0, time.Local), info.Time)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "RECIPE.CSV", entries[0].Name)
+	assert.False(t, entries[0].IsDir())
+	assert.True(t, entries[1].IsDir())
+
+	mt.push(append([]byte{0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05}, "a,b,c"...))
+	data, err := NewFileFS(f, DiskMemoryCard).ReadFile("LOGS/RECIPE.CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a,b,c"), data)
+	assert.Equal(t, append([]byte{0x22, 0x02, 0x80, 0x00}, append([]byte("RECIPE  .CSV"), 0x00, 0x00, 0x00, 0x00, 0x03, 0xde, 0x00, 0x05, '\\', 'L', 'O', 'G', 'S')...), mt.req)
+
+	assert.NoError(t, f.RenameFile(DiskEMFile, "", "A.TXT", "B"))
+	assert.Equal(t, append([]byte{0x22, 0x08, 0x80, 0x01, 0x00, 0x00}, []byte("A       .TXTB           ")...), mt.req)
+
+	assert.Error(t, f.DeleteFiles(DiskMemoryCard, "", "TOOLONGNAME.CSV"))
+}
+
+func TestFinsLoopback(t *testing.T) {
+	f, mt := newMockFins([]byte("hello"))
+
+	_, err := f.Loopback([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x01, 'h', 'e', 'l', 'l', 'o'}, mt.req)
+
+	mt.push([]byte("hellO"))
+	_, err = f.Loopback([]byte("hello"))
+	assert.Error(t, err)
+
+	assert.NoError(t, f.BroadcastTestSend([]byte{0x01}))
+	assert.Equal(t, byte(0xff), mt.header.DA1)
+	assert.Equal(t, byte(0x81), mt.header.ICF)
+
+	mt.push([]byte{0x00, 0x03})
+	count, err := f.BroadcastTestResults()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(3), count)
+}
+
+func TestFinsCycleTime(t *testing.T) {
+	f, mt := newMockFins([]byte{0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x01, 0x2c, 0x00, 0x00, 0x00, 0x32})
+
+	ct, err := f.CycleTime()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x20, 0x01}, mt.req)
+	assert.Equal(t, &CycleTime{Average: 10 * time.Millisecond, Max: 30 * time.Millisecond, Min: 5 * time.Millisecond}, ct)
+
+	assert.NoError(t, f.InitCycleTime())
+	assert.Equal(t, []byte{0x06, 0x20, 0x00}, mt.req)
+}
+
+func TestFinsNetworkStatus(t *testing.T) {
+	network := make([]byte, networkStatusSize)
+	network[0] = 0x10
+	network[1] = 0x01
+	// cycle time 12.3ms, polling node 2, node 3 not participating in cyclic transmission
+	network[31], network[32] = 0x00, 0x7b
+	network[33] = 0x02
+	network[36] = 0x08
+	dataLink := make([]byte, dataLinkStatusSize)
+	dataLink[0] = 0x01
+	dataLink[1] = 0x02
+	dataLink[2+4] = 0x01
+	f, mt := newMockFins(network, dataLink)
+
+	ns, err := f.NetworkStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x02}, mt.req)
+	assert.Equal(t, []byte{2, 3}, ns.ParticipatingNodes())
+	assert.Equal(t, 12300*time.Microsecond, ns.CycleTime)
+	assert.Equal(t, byte(2), ns.PollingNode)
+	assert.Equal(t, uint64(1)<<3, ns.CyclicNonParticipation)
+	assert.Equal(t, 52, networkStatusSize)
+
+	ds, err := f.DataLinkStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x03}, mt.req)
+	assert.True(t, ds.Active())
+	assert.Equal(t, byte(2), ds.MasterNode)
+	assert.Equal(t, []byte{5}, ds.ActiveNodes())
+}
+
+func TestFinsReadNonFatalCpuError(t *testing.T) {
+	f, mt := newMockFins([]byte{0x12, 0x34})
+	mt.endCode = EndCode{0x00, 0x40}
+
+	var cpuErr *FinsError
+	f.SetCpuErrorCallback(func(err *FinsError) {
+		cpuErr = err
+	})
+
+	values, err := f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x1234), values[0].Uint16())
+	assert.ErrorIs(t, cpuErr, NonFatalCpuUnitError)
+	assert.Equal(t, [2]byte{0x01, 0x01}, cpuErr.CommandCode)
+}
+
+func TestFinsConcurrentRequests(t *testing.T) {
+	f, mt := newMockFins()
+	for i := 0; i < 50; i++ {
+		mt.push([]byte{0x00, byte(i)})
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values, err := f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+			assert.NoError(t, err)
+			assert.Len(t, values, 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, f.pending)
+}
+
+func TestFinsReadContext(t *testing.T) {
+	f, mt := newMockFins([]byte{0x12, 0x34})
+
+	values, err := f.ReadContext(context.Background(), &FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x1234), values[0].Uint16())
+
+	mt.silent = true
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = f.ReadContext(ctx, &FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, f.pending)
+}
+
+func TestFinsRoute(t *testing.T) {
+	f, mt := newMockFins([]byte{0x12, 0x34}, make([]byte, controllerStatusSize), []byte{0x56, 0x78})
+
+	routed, err := f.Route(NodeAddress{Network: 2, Node: 5, Unit: 0x10})
+	assert.NoError(t, err)
+
+	_, err = routed.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 5, 0x10}, []byte{mt.header.DNA, mt.header.DA1, mt.header.DA2})
+
+	// every command is routed, not only the memory area ones
+	_, err = routed.ControllerStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 5, 0x10}, []byte{mt.header.DNA, mt.header.DA1, mt.header.DA2})
+
+	_, err = f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0}, []byte{mt.header.DNA, mt.header.DA1, mt.header.DA2})
+
+	_, err = f.Route(NodeAddress{Network: 2, Unit: 0x10})
+	assert.Error(t, err)
+
+	// the cpu error callback set on f after routing applies to the route too
+	var cpuErr *FinsError
+	f.SetCpuErrorCallback(func(err *FinsError) {
+		cpuErr = err
+	})
+	mt.endCode = EndCode{0x00, 0x40}
+	mt.push(make([]byte, controllerStatusSize))
+	_, err = routed.ControllerStatus()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, cpuErr, NonFatalCpuUnitError)
+}
+
+func TestFinsResponseTimeoutDisconnects(t *testing.T) {
+	f, mt := newMockFins()
+	mt.ReadTimeout = 10 * time.Millisecond
+	mt.silent = true
+
+	for i := 0; i < maxResponseTimeouts; i++ {
+		assert.Equal(t, StateConnected, mt.State())
+		_, err := f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, StateDisconnected, mt.State())
+}
+
+func TestFinsRequestAfterClose(t *testing.T) {
+	f, mt := newMockFins()
+	assert.NoError(t, f.Close())
+
+	_, err := f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.ErrorIs(t, f.BroadcastTestSend([]byte{0x01}), ErrClosed)
+	assert.Nil(t, mt.requests())
+}
+
+// cancelWriter cancels the ctx on the first write.
+type cancelWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.Buffer.Write(p)
+}
+
+func TestFinsMultiRoundTripContext(t *testing.T) {
+	first := append([]byte{0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe4}, bytes.Repeat([]byte{0x11}, ProgramChunkSize)...)
+	f, mt := newMockFins(first)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &cancelWriter{cancel: cancel}
+	n, err := BackupProgramContext(ctx, f, w)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(ProgramChunkSize), n)
+	assert.Equal(t, []byte{0x03, 0x06, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe4}, mt.req)
+
+	_, err = f.ReadParameterAreaContext(ctx, ParameterAreaPlcSetup)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = f.ReadFileContext(ctx, DiskMemoryCard, "", "A.TXT")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = f.ControllerDataContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFinsSingleRoundTripContext(t *testing.T) {
+	f, mt := newMockFins()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	address := &FinAddress{AreaCode: MemoryAreaCIOBit, Address: 0}
+	calls := map[string]func() error{
+		"ControllerStatus": func() error { _, err := f.ControllerStatusContext(ctx); return err },
+		"Run":              func() error { return f.RunContext(ctx, OperatingModeRun) },
+		"Stop":             func() error { return f.StopContext(ctx) },
+		"ReadClock":        func() error { _, err := f.ReadClockContext(ctx); return err },
+		"WriteClock":       func() error { return f.WriteClockContext(ctx, time.Now()) },
+		"CycleTime":        func() error { _, err := f.CycleTimeContext(ctx); return err },
+		"NetworkStatus":    func() error { _, err := f.NetworkStatusContext(ctx); return err },
+		"ForceSet":         func() error { return f.ForceSetContext(ctx, address) },
+		"AcquireAccess":    func() error { return f.AcquireAccessRightContext(ctx) },
+		"ClearError":       func() error { return f.ClearErrorContext(ctx, ErrorClearAll) },
+		"ReadErrorLog":     func() error { _, err := f.ReadErrorLogContext(ctx, 0, 1); return err },
+		"Loopback":         func() error { _, err := f.LoopbackContext(ctx, []byte{0x01}); return err },
+		"DeleteFiles":      func() error { return f.DeleteFilesContext(ctx, DiskMemoryCard, "", "A.TXT") },
+		"CreateDirectory":  func() error { return f.CreateDirectoryContext(ctx, DiskMemoryCard, "", "DIR") },
+		"WriteProgram":     func() error { return f.WriteProgramContext(ctx, 0, []byte{0x01}, true) },
+		"ClearParameter":   func() error { return f.ClearParameterAreaContext(ctx, ParameterAreaPlcSetup) },
+	}
+
+	for name, call := range calls {
+		assert.ErrorIs(t, call(), context.Canceled, name)
+	}
+	assert.Nil(t, mt.requests())
+}
@@ -42,6 +42,85 @@ const (
 	CommandMultipleMemoryRead
 	// CommandMemoryTransfer is a Command of type MemoryTransfer.
 	CommandMemoryTransfer
+	// CommandParameterAreaRead is a Command of type ParameterAreaRead.
+	CommandParameterAreaRead
+	// CommandParameterAreaWrite is a Command of type ParameterAreaWrite.
+	CommandParameterAreaWrite
+	// CommandParameterAreaClear is a Command of type ParameterAreaClear.
+	CommandParameterAreaClear
+	// CommandProgramAreaRead is a Command of type ProgramAreaRead.
+	CommandProgramAreaRead
+	// CommandProgramAreaWrite is a Command of type ProgramAreaWrite.
+	CommandProgramAreaWrite
+	// CommandProgramAreaClear is a Command of type ProgramAreaClear.
+	CommandProgramAreaClear
+	// CommandRun is a Command of type Run.
+	CommandRun
+	// CommandStop is a Command of type Stop.
+	CommandStop
+	// CommandControllerDataRead is a Command of type ControllerDataRead.
+	CommandControllerDataRead
+	// CommandControllerStatusRead is a Command of type ControllerStatusRead.
+	CommandControllerStatusRead
+	// CommandNetworkStatusRead is a Command of type NetworkStatusRead.
+	CommandNetworkStatusRead
+	// CommandDataLinkStatusRead is a Command of type DataLinkStatusRead.
+	CommandDataLinkStatusRead
+	// CommandCycleTimeRead is a Command of type CycleTimeRead.
+	CommandCycleTimeRead
+	// CommandClockRead is a Command of type ClockRead.
+	CommandClockRead
+	// CommandClockWrite is a Command of type ClockWrite.
+	CommandClockWrite
+	// CommandLoopbackTest is a Command of type LoopbackTest.
+	CommandLoopbackTest
+	// CommandBroadcastTestResultsRead is a Command of type BroadcastTestResultsRead.
+	CommandBroadcastTestResultsRead
+	// CommandBroadcastTestDataSend is a Command of type BroadcastTestDataSend.
+	CommandBroadcastTestDataSend
+	// CommandMessageRead is a Command of type MessageRead.
+	CommandMessageRead
+	// CommandAccessRightAcquire is a Command of type AccessRightAcquire.
+	CommandAccessRightAcquire
+	// CommandAccessRightForcedAcquire is a Command of type AccessRightForcedAcquire.
+	CommandAccessRightForcedAcquire
+	// CommandAccessRightRelease is a Command of type AccessRightRelease.
+	CommandAccessRightRelease
+	// CommandErrorClear is a Command of type ErrorClear.
+	CommandErrorClear
+	// CommandErrorLogRead is a Command of type ErrorLogRead.
+	CommandErrorLogRead
+	// CommandErrorLogClear is a Command of type ErrorLogClear.
+	CommandErrorLogClear
+	// CommandFileNameRead is a Command of type FileNameRead.
+	CommandFileNameRead
+	// CommandSingleFileRead is a Command of type SingleFileRead.
+	CommandSingleFileRead
+	// CommandSingleFileWrite is a Command of type SingleFileWrite.
+	CommandSingleFileWrite
+	// CommandFileMemoryFormat is a Command of type FileMemoryFormat.
+	CommandFileMemoryFormat
+	// CommandFileDelete is a Command of type FileDelete.
+	CommandFileDelete
+	// CommandFileCopy is a Command of type FileCopy.
+	CommandFileCopy
+	// CommandFileNameChange is a Command of type FileNameChange.
+	CommandFileNameChange
+	// CommandDirectoryCreateDelete is a Command of type DirectoryCreateDelete.
+	CommandDirectoryCreateDelete
+	// CommandForcedSetReset is a Command of type ForcedSetReset.
+	CommandForcedSetReset
+	// CommandForcedSetResetCancel is a Command of type ForcedSetResetCancel.
+	CommandForcedSetResetCancel
+)
+
+const (
+	// CpuStatusStop is a CpuStatus of type Stop.
+	CpuStatusStop CpuStatus = 0
+	// CpuStatusRun is a CpuStatus of type Run.
+	CpuStatusRun CpuStatus = 1
+	// CpuStatusStandby is a CpuStatus of type Standby.
+	CpuStatusStandby CpuStatus = 128
 )
 
 const (
@@ -68,6 +147,26 @@ const (
 	DataTypePV DataType = "PV"
 )
 
+const (
+	// DiskMemoryCard is a Disk of type MemoryCard.
+	DiskMemoryCard Disk = 32768
+	// DiskEMFile is a Disk of type EMFile.
+	DiskEMFile Disk = 32769
+)
+
+const (
+	// ForceActionReset is a ForceAction of type Reset.
+	ForceActionReset ForceAction = 0
+	// ForceActionSet is a ForceAction of type Set.
+	ForceActionSet ForceAction = 1
+	// ForceActionReleaseOff is a ForceAction of type ReleaseOff.
+	ForceActionReleaseOff ForceAction = 32768
+	// ForceActionReleaseOn is a ForceAction of type ReleaseOn.
+	ForceActionReleaseOn ForceAction = 32769
+	// ForceActionRelease is a ForceAction of type Release.
+	ForceActionRelease ForceAction = 65535
+)
+
 const (
 	// MCNormalCompletion is a MC of type NormalCompletion.
 	MCNormalCompletion MC = 0
@@ -156,6 +255,28 @@ const (
 	MemoryAreaDRPV MemoryArea = "DRPV" // Data Register
 )
 
+const (
+	// OperatingModeProgram is a OperatingMode of type Program.
+	OperatingModeProgram OperatingMode = 0
+	// OperatingModeDebug is a OperatingMode of type Debug.
+	OperatingModeDebug OperatingMode = 1
+	// OperatingModeMonitor is a OperatingMode of type Monitor.
+	OperatingModeMonitor OperatingMode = 2
+	// OperatingModeRun is a OperatingMode of type Run.
+	OperatingModeRun OperatingMode = 4
+)
+
+const (
+	// ParameterAreaPlcSetup is a ParameterArea of type PlcSetup.
+	ParameterAreaPlcSetup ParameterArea = iota
+	// ParameterAreaIOTable is a ParameterArea of type IOTable.
+	ParameterAreaIOTable
+	// ParameterAreaRoutingTable is a ParameterArea of type RoutingTable.
+	ParameterAreaRoutingTable
+	// ParameterAreaCpuBusUnitSetup is a ParameterArea of type CpuBusUnitSetup.
+	ParameterAreaCpuBusUnitSetup
+)
+
 const (
 	// PlcTypeNew is a PlcType of type New.
 	PlcTypeNew PlcType = iota
@@ -253,14 +374,49 @@ func ParseArea(value string) (Area, error) {
 
 var ErrInvalidCommand = errors.New("not a valid Command")
 
-var _CommandName = "MemoryReadMemoryWriteMemoryFillMultipleMemoryReadMemoryTransfer"
+var _CommandName = "MemoryReadMemoryWriteMemoryFillMultipleMemoryReadMemoryTransferParameterAreaReadParameterAreaWriteParameterAreaClearProgramAreaReadProgramAreaWriteProgramAreaClearRunStopControllerDataReadControllerStatusReadNetworkStatusReadDataLinkStatusReadCycleTimeReadClockReadClockWriteLoopbackTestBroadcastTestResultsReadBroadcastTestDataSendMessageReadAccessRightAcquireAccessRightForcedAcquireAccessRightReleaseErrorClearErrorLogReadErrorLogClearFileNameReadSingleFileReadSingleFileWriteFileMemoryFormatFileDeleteFileCopyFileNameChangeDirectoryCreateDeleteForcedSetResetForcedSetResetCancel"
 
 var _CommandMapName = map[Command]string{
-	CommandMemoryRead:         _CommandName[0:10],
-	CommandMemoryWrite:        _CommandName[10:21],
-	CommandMemoryFill:         _CommandName[21:31],
-	CommandMultipleMemoryRead: _CommandName[31:49],
-	CommandMemoryTransfer:     _CommandName[49:63],
+	CommandMemoryRead:               _CommandName[0:10],
+	CommandMemoryWrite:              _CommandName[10:21],
+	CommandMemoryFill:               _CommandName[21:31],
+	CommandMultipleMemoryRead:       _CommandName[31:49],
+	CommandMemoryTransfer:           _CommandName[49:63],
+	CommandParameterAreaRead:        _CommandName[63:80],
+	CommandParameterAreaWrite:       _CommandName[80:98],
+	CommandParameterAreaClear:       _CommandName[98:116],
+	CommandProgramAreaRead:          _CommandName[116:131],
+	CommandProgramAreaWrite:         _CommandName[131:147],
+	CommandProgramAreaClear:         _CommandName[147:163],
+	CommandRun:                      _CommandName[163:166],
+	CommandStop:                     _CommandName[166:170],
+	CommandControllerDataRead:       _CommandName[170:188],
+	CommandControllerStatusRead:     _CommandName[188:208],
+	CommandNetworkStatusRead:        _CommandName[208:225],
+	CommandDataLinkStatusRead:       _CommandName[225:243],
+	CommandCycleTimeRead:            _CommandName[243:256],
+	CommandClockRead:                _CommandName[256:265],
+	CommandClockWrite:               _CommandName[265:275],
+	CommandLoopbackTest:             _CommandName[275:287],
+	CommandBroadcastTestResultsRead: _CommandName[287:311],
+	CommandBroadcastTestDataSend:    _CommandName[311:332],
+	CommandMessageRead:              _CommandName[332:343],
+	CommandAccessRightAcquire:       _CommandName[343:361],
+	CommandAccessRightForcedAcquire: _CommandName[361:385],
+	CommandAccessRightRelease:       _CommandName[385:403],
+	CommandErrorClear:               _CommandName[403:413],
+	CommandErrorLogRead:             _CommandName[413:425],
+	CommandErrorLogClear:            _CommandName[425:438],
+	CommandFileNameRead:             _CommandName[438:450],
+	CommandSingleFileRead:           _CommandName[450:464],
+	CommandSingleFileWrite:          _CommandName[464:479],
+	CommandFileMemoryFormat:         _CommandName[479:495],
+	CommandFileDelete:               _CommandName[495:505],
+	CommandFileCopy:                 _CommandName[505:513],
+	CommandFileNameChange:           _CommandName[513:527],
+	CommandDirectoryCreateDelete:    _CommandName[527:548],
+	CommandForcedSetReset:           _CommandName[548:562],
+	CommandForcedSetResetCancel:     _CommandName[562:582],
 }
 
 // Name is the attribute of Command.
@@ -272,11 +428,46 @@ func (x Command) Name() string {
 }
 
 var _CommandMapMr = map[Command]uint8{
-	CommandMemoryRead:         1,
-	CommandMemoryWrite:        1,
-	CommandMemoryFill:         1,
-	CommandMultipleMemoryRead: 1,
-	CommandMemoryTransfer:     1,
+	CommandMemoryRead:               1,
+	CommandMemoryWrite:              1,
+	CommandMemoryFill:               1,
+	CommandMultipleMemoryRead:       1,
+	CommandMemoryTransfer:           1,
+	CommandParameterAreaRead:        2,
+	CommandParameterAreaWrite:       2,
+	CommandParameterAreaClear:       2,
+	CommandProgramAreaRead:          3,
+	CommandProgramAreaWrite:         3,
+	CommandProgramAreaClear:         3,
+	CommandRun:                      4,
+	CommandStop:                     4,
+	CommandControllerDataRead:       5,
+	CommandControllerStatusRead:     6,
+	CommandNetworkStatusRead:        6,
+	CommandDataLinkStatusRead:       6,
+	CommandCycleTimeRead:            6,
+	CommandClockRead:                7,
+	CommandClockWrite:               7,
+	CommandLoopbackTest:             8,
+	CommandBroadcastTestResultsRead: 8,
+	CommandBroadcastTestDataSend:    8,
+	CommandMessageRead:              9,
+	CommandAccessRightAcquire:       12,
+	CommandAccessRightForcedAcquire: 12,
+	CommandAccessRightRelease:       12,
+	CommandErrorClear:               33,
+	CommandErrorLogRead:             33,
+	CommandErrorLogClear:            33,
+	CommandFileNameRead:             34,
+	CommandSingleFileRead:           34,
+	CommandSingleFileWrite:          34,
+	CommandFileMemoryFormat:         34,
+	CommandFileDelete:               34,
+	CommandFileCopy:                 34,
+	CommandFileNameChange:           34,
+	CommandDirectoryCreateDelete:    34,
+	CommandForcedSetReset:           35,
+	CommandForcedSetResetCancel:     35,
 }
 
 // Mr is the attribute of Command.
@@ -288,11 +479,46 @@ func (x Command) Mr() uint8 {
 }
 
 var _CommandMapSr = map[Command]uint8{
-	CommandMemoryRead:         1,
-	CommandMemoryWrite:        2,
-	CommandMemoryFill:         3,
-	CommandMultipleMemoryRead: 4,
-	CommandMemoryTransfer:     5,
+	CommandMemoryRead:               1,
+	CommandMemoryWrite:              2,
+	CommandMemoryFill:               3,
+	CommandMultipleMemoryRead:       4,
+	CommandMemoryTransfer:           5,
+	CommandParameterAreaRead:        1,
+	CommandParameterAreaWrite:       2,
+	CommandParameterAreaClear:       3,
+	CommandProgramAreaRead:          6,
+	CommandProgramAreaWrite:         7,
+	CommandProgramAreaClear:         8,
+	CommandRun:                      1,
+	CommandStop:                     2,
+	CommandControllerDataRead:       1,
+	CommandControllerStatusRead:     1,
+	CommandNetworkStatusRead:        2,
+	CommandDataLinkStatusRead:       3,
+	CommandCycleTimeRead:            32,
+	CommandClockRead:                1,
+	CommandClockWrite:               2,
+	CommandLoopbackTest:             1,
+	CommandBroadcastTestResultsRead: 2,
+	CommandBroadcastTestDataSend:    3,
+	CommandMessageRead:              32,
+	CommandAccessRightAcquire:       1,
+	CommandAccessRightForcedAcquire: 2,
+	CommandAccessRightRelease:       3,
+	CommandErrorClear:               1,
+	CommandErrorLogRead:             2,
+	CommandErrorLogClear:            3,
+	CommandFileNameRead:             1,
+	CommandSingleFileRead:           2,
+	CommandSingleFileWrite:          3,
+	CommandFileMemoryFormat:         4,
+	CommandFileDelete:               5,
+	CommandFileCopy:                 7,
+	CommandFileNameChange:           8,
+	CommandDirectoryCreateDelete:    21,
+	CommandForcedSetReset:           1,
+	CommandForcedSetResetCancel:     2,
 }
 
 // Sr is the attribute of Command.
@@ -321,11 +547,46 @@ func (x Command) String() string {
 }
 
 var _CommandNameMap = map[string]Command{
-	_CommandName[0:10]:  CommandMemoryRead,
-	_CommandName[10:21]: CommandMemoryWrite,
-	_CommandName[21:31]: CommandMemoryFill,
-	_CommandName[31:49]: CommandMultipleMemoryRead,
-	_CommandName[49:63]: CommandMemoryTransfer,
+	_CommandName[0:10]:    CommandMemoryRead,
+	_CommandName[10:21]:   CommandMemoryWrite,
+	_CommandName[21:31]:   CommandMemoryFill,
+	_CommandName[31:49]:   CommandMultipleMemoryRead,
+	_CommandName[49:63]:   CommandMemoryTransfer,
+	_CommandName[63:80]:   CommandParameterAreaRead,
+	_CommandName[80:98]:   CommandParameterAreaWrite,
+	_CommandName[98:116]:  CommandParameterAreaClear,
+	_CommandName[116:131]: CommandProgramAreaRead,
+	_CommandName[131:147]: CommandProgramAreaWrite,
+	_CommandName[147:163]: CommandProgramAreaClear,
+	_CommandName[163:166]: CommandRun,
+	_CommandName[166:170]: CommandStop,
+	_CommandName[170:188]: CommandControllerDataRead,
+	_CommandName[188:208]: CommandControllerStatusRead,
+	_CommandName[208:225]: CommandNetworkStatusRead,
+	_CommandName[225:243]: CommandDataLinkStatusRead,
+	_CommandName[243:256]: CommandCycleTimeRead,
+	_CommandName[256:265]: CommandClockRead,
+	_CommandName[265:275]: CommandClockWrite,
+	_CommandName[275:287]: CommandLoopbackTest,
+	_CommandName[287:311]: CommandBroadcastTestResultsRead,
+	_CommandName[311:332]: CommandBroadcastTestDataSend,
+	_CommandName[332:343]: CommandMessageRead,
+	_CommandName[343:361]: CommandAccessRightAcquire,
+	_CommandName[361:385]: CommandAccessRightForcedAcquire,
+	_CommandName[385:403]: CommandAccessRightRelease,
+	_CommandName[403:413]: CommandErrorClear,
+	_CommandName[413:425]: CommandErrorLogRead,
+	_CommandName[425:438]: CommandErrorLogClear,
+	_CommandName[438:450]: CommandFileNameRead,
+	_CommandName[450:464]: CommandSingleFileRead,
+	_CommandName[464:479]: CommandSingleFileWrite,
+	_CommandName[479:495]: CommandFileMemoryFormat,
+	_CommandName[495:505]: CommandFileDelete,
+	_CommandName[505:513]: CommandFileCopy,
+	_CommandName[513:527]: CommandFileNameChange,
+	_CommandName[527:548]: CommandDirectoryCreateDelete,
+	_CommandName[548:562]: CommandForcedSetReset,
+	_CommandName[562:582]: CommandForcedSetResetCancel,
 }
 
 // ParseCommand converts a string to a Command.
@@ -336,6 +597,55 @@ func ParseCommand(value string) (Command, error) {
 	return Command(0), fmt.Errorf("%s is %w", value, ErrInvalidCommand)
 }
 
+var ErrInvalidCpuStatus = errors.New("not a valid CpuStatus")
+
+var _CpuStatusName = "StopRunStandby"
+
+var _CpuStatusMapName = map[CpuStatus]string{
+	CpuStatusStop:    _CpuStatusName[0:4],
+	CpuStatusRun:     _CpuStatusName[4:7],
+	CpuStatusStandby: _CpuStatusName[7:14],
+}
+
+// Name is the attribute of CpuStatus.
+func (x CpuStatus) Name() string {
+	if v, ok := _CpuStatusMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("CpuStatus(%d).Name", x)
+}
+
+// Val is the attribute of CpuStatus.
+func (x CpuStatus) Val() uint8 {
+	return uint8(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x CpuStatus) IsValid() bool {
+	_, ok := _CpuStatusMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x CpuStatus) String() string {
+	return x.Name()
+}
+
+var _CpuStatusNameMap = map[string]CpuStatus{
+	_CpuStatusName[0:4]:  CpuStatusStop,
+	_CpuStatusName[4:7]:  CpuStatusRun,
+	_CpuStatusName[7:14]: CpuStatusStandby,
+}
+
+// ParseCpuStatus converts a string to a CpuStatus.
+func ParseCpuStatus(value string) (CpuStatus, error) {
+	if x, ok := _CpuStatusNameMap[value]; ok {
+		return x, nil
+	}
+	return CpuStatus(0), fmt.Errorf("%s is %w", value, ErrInvalidCpuStatus)
+}
+
 var ErrInvalidDataClass = errors.New("not a valid DataClass")
 
 var _DataClassName = "CommandResponse"
@@ -428,6 +738,106 @@ func ParseDataType(value string) (DataType, error) {
 	return "", fmt.Errorf("%s is %w", value, ErrInvalidDataType)
 }
 
+var ErrInvalidDisk = errors.New("not a valid Disk")
+
+var _DiskName = "MemoryCardEMFile"
+
+var _DiskMapName = map[Disk]string{
+	DiskMemoryCard: _DiskName[0:10],
+	DiskEMFile:     _DiskName[10:16],
+}
+
+// Name is the attribute of Disk.
+func (x Disk) Name() string {
+	if v, ok := _DiskMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("Disk(%d).Name", x)
+}
+
+// Val is the attribute of Disk.
+func (x Disk) Val() uint16 {
+	return uint16(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Disk) IsValid() bool {
+	_, ok := _DiskMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x Disk) String() string {
+	return x.Name()
+}
+
+var _DiskNameMap = map[string]Disk{
+	_DiskName[0:10]:  DiskMemoryCard,
+	_DiskName[10:16]: DiskEMFile,
+}
+
+// ParseDisk converts a string to a Disk.
+func ParseDisk(value string) (Disk, error) {
+	if x, ok := _DiskNameMap[value]; ok {
+		return x, nil
+	}
+	return Disk(0), fmt.Errorf("%s is %w", value, ErrInvalidDisk)
+}
+
+var ErrInvalidForceAction = errors.New("not a valid ForceAction")
+
+var _ForceActionName = "ResetSetReleaseOffReleaseOnRelease"
+
+var _ForceActionMapName = map[ForceAction]string{
+	ForceActionReset:      _ForceActionName[0:5],
+	ForceActionSet:        _ForceActionName[5:8],
+	ForceActionReleaseOff: _ForceActionName[8:18],
+	ForceActionReleaseOn:  _ForceActionName[18:27],
+	ForceActionRelease:    _ForceActionName[27:34],
+}
+
+// Name is the attribute of ForceAction.
+func (x ForceAction) Name() string {
+	if v, ok := _ForceActionMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("ForceAction(%d).Name", x)
+}
+
+// Val is the attribute of ForceAction.
+func (x ForceAction) Val() uint16 {
+	return uint16(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ForceAction) IsValid() bool {
+	_, ok := _ForceActionMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x ForceAction) String() string {
+	return x.Name()
+}
+
+var _ForceActionNameMap = map[string]ForceAction{
+	_ForceActionName[0:5]:   ForceActionReset,
+	_ForceActionName[5:8]:   ForceActionSet,
+	_ForceActionName[8:18]:  ForceActionReleaseOff,
+	_ForceActionName[18:27]: ForceActionReleaseOn,
+	_ForceActionName[27:34]: ForceActionRelease,
+}
+
+// ParseForceAction converts a string to a ForceAction.
+func ParseForceAction(value string) (ForceAction, error) {
+	if x, ok := _ForceActionNameMap[value]; ok {
+		return x, nil
+	}
+	return ForceAction(0), fmt.Errorf("%s is %w", value, ErrInvalidForceAction)
+}
+
 var ErrInvalidMC = errors.New("not a valid MC")
 
 var _MCName = "NormalCompletionLocalNodeErrorDestinationNodeErrorControllerErrorServiceUnsupportedRoutingTableErrorCommandFormatErrorParameterErrorReadNotPossibleWriteNotPossibleNotExecutableInCurrentModeNoSuchDeviceCannotStartStopUnitErrorCommandErrorAccessRightErrorAbort"
@@ -918,6 +1328,138 @@ func MustParseMemoryArea(value string) MemoryArea {
 	return val
 }
 
+var ErrInvalidOperatingMode = errors.New("not a valid OperatingMode")
+
+var _OperatingModeName = "ProgramDebugMonitorRun"
+
+var _OperatingModeMapName = map[OperatingMode]string{
+	OperatingModeProgram: _OperatingModeName[0:7],
+	OperatingModeDebug:   _OperatingModeName[7:12],
+	OperatingModeMonitor: _OperatingModeName[12:19],
+	OperatingModeRun:     _OperatingModeName[19:22],
+}
+
+// Name is the attribute of OperatingMode.
+func (x OperatingMode) Name() string {
+	if v, ok := _OperatingModeMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("OperatingMode(%d).Name", x)
+}
+
+// Val is the attribute of OperatingMode.
+func (x OperatingMode) Val() uint8 {
+	return uint8(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x OperatingMode) IsValid() bool {
+	_, ok := _OperatingModeMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x OperatingMode) String() string {
+	return x.Name()
+}
+
+var _OperatingModeNameMap = map[string]OperatingMode{
+	_OperatingModeName[0:7]:   OperatingModeProgram,
+	_OperatingModeName[7:12]:  OperatingModeDebug,
+	_OperatingModeName[12:19]: OperatingModeMonitor,
+	_OperatingModeName[19:22]: OperatingModeRun,
+}
+
+// ParseOperatingMode converts a string to a OperatingMode.
+func ParseOperatingMode(value string) (OperatingMode, error) {
+	if x, ok := _OperatingModeNameMap[value]; ok {
+		return x, nil
+	}
+	return OperatingMode(0), fmt.Errorf("%s is %w", value, ErrInvalidOperatingMode)
+}
+
+var ErrInvalidParameterArea = errors.New("not a valid ParameterArea")
+
+var _ParameterAreaName = "PlcSetupIOTableRoutingTableCpuBusUnitSetup"
+
+var _ParameterAreaMapName = map[ParameterArea]string{
+	ParameterAreaPlcSetup:        _ParameterAreaName[0:8],
+	ParameterAreaIOTable:         _ParameterAreaName[8:15],
+	ParameterAreaRoutingTable:    _ParameterAreaName[15:27],
+	ParameterAreaCpuBusUnitSetup: _ParameterAreaName[27:42],
+}
+
+// Name is the attribute of ParameterArea.
+func (x ParameterArea) Name() string {
+	if v, ok := _ParameterAreaMapName[x]; ok {
+		return v
+	}
+	return fmt.Sprintf("ParameterArea(%d).Name", x)
+}
+
+var _ParameterAreaMapCode = map[ParameterArea]uint16{
+	ParameterAreaPlcSetup:        32784,
+	ParameterAreaIOTable:         32786,
+	ParameterAreaRoutingTable:    32787,
+	ParameterAreaCpuBusUnitSetup: 32770,
+}
+
+// Code is the attribute of ParameterArea.
+func (x ParameterArea) Code() uint16 {
+	if v, ok := _ParameterAreaMapCode[x]; ok {
+		return v
+	}
+	return 0
+}
+
+var _ParameterAreaMapWords = map[ParameterArea]uint16{
+	ParameterAreaPlcSetup:        512,
+	ParameterAreaIOTable:         1280,
+	ParameterAreaRoutingTable:    512,
+	ParameterAreaCpuBusUnitSetup: 5428,
+}
+
+// Words is the attribute of ParameterArea.
+func (x ParameterArea) Words() uint16 {
+	if v, ok := _ParameterAreaMapWords[x]; ok {
+		return v
+	}
+	return 0
+}
+
+// Val is the attribute of ParameterArea.
+func (x ParameterArea) Val() int {
+	return int(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ParameterArea) IsValid() bool {
+	_, ok := _ParameterAreaMapName[x]
+	return ok
+}
+
+// String implements the Stringer interface.
+func (x ParameterArea) String() string {
+	return x.Name()
+}
+
+var _ParameterAreaNameMap = map[string]ParameterArea{
+	_ParameterAreaName[0:8]:   ParameterAreaPlcSetup,
+	_ParameterAreaName[8:15]:  ParameterAreaIOTable,
+	_ParameterAreaName[15:27]: ParameterAreaRoutingTable,
+	_ParameterAreaName[27:42]: ParameterAreaCpuBusUnitSetup,
+}
+
+// ParseParameterArea converts a string to a ParameterArea.
+func ParseParameterArea(value string) (ParameterArea, error) {
+	if x, ok := _ParameterAreaNameMap[value]; ok {
+		return x, nil
+	}
+	return ParameterArea(0), fmt.Errorf("%s is %w", value, ErrInvalidParameterArea)
+}
+
 var ErrInvalidPlcType = errors.New("not a valid PlcType")
 
 var _PlcTypeName = "NewOld"
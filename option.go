@@ -0,0 +1,140 @@
+package fins
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures the Fins created by NewFins.
+type Option func(o *options)
+
+type options struct {
+	gct byte
+	dst NodeAddress
+	src NodeAddress
+
+	readTimeout          *time.Duration
+	writeTimeout         *time.Duration
+	dialTimeout          *time.Duration
+	reconnectionInterval *time.Duration
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := &options{gct: 2}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o, o.validate()
+}
+
+// WithDestination sets the destination address (DNA, DA1, DA2) of all requests,
+// node 0 keeps the node of the transporter: the last octet of the plc IPv4 address for udp,
+// or the node returned by the node address handshake for tcp.
+func WithDestination(dst NodeAddress) Option {
+	return func(o *options) {
+		o.dst = dst
+	}
+}
+
+// WithSource sets the source address (SNA, SA1, SA2) of all requests,
+// node 0 keeps the node of the transporter: the last octet of the local IPv4 address for udp,
+// or the node assigned by the node address handshake for tcp.
+func WithSource(src NodeAddress) Option {
+	return func(o *options) {
+		o.src = src
+	}
+}
+
+// WithGatewayCount sets the GCT, the number of networks a frame may cross minus one.
+func WithGatewayCount(gct byte) Option {
+	return func(o *options) {
+		o.gct = gct
+	}
+}
+
+// WithReadTimeout sets how long a request waits for its response.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.readTimeout = &timeout
+	}
+}
+
+// WithWriteTimeout sets how long writing a request frame may take.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.writeTimeout = &timeout
+	}
+}
+
+// WithDialTimeout sets how long connecting to the plc may take.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = &timeout
+	}
+}
+
+// WithReconnectionInterval sets the delay before reconnecting a lost connection, 0 disables reconnection.
+func WithReconnectionInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reconnectionInterval = &interval
+	}
+}
+
+func (o *options) validate() error {
+	if o.gct > 7 {
+		return fmt.Errorf("gateway count %d out of range 0 to 7", o.gct)
+	}
+
+	if err := o.dst.validateDestination(); err != nil {
+		return err
+	}
+
+	if err := o.src.validateSource(); err != nil {
+		return err
+	}
+
+	for name, timeout := range map[string]*time.Duration{"read": o.readTimeout, "write": o.writeTimeout, "dial": o.dialTimeout} {
+		if timeout != nil && *timeout <= 0 {
+			return fmt.Errorf("%s timeout must be positive, got %s", name, *timeout)
+		}
+	}
+
+	if o.reconnectionInterval != nil && *o.reconnectionInterval < 0 {
+		return fmt.Errorf("reconnection interval must not be negative, got %s", *o.reconnectionInterval)
+	}
+
+	return nil
+}
+
+func (o *options) applyTransporter(t *baseTransporter) {
+	if o.readTimeout != nil {
+		t.ReadTimeout = *o.readTimeout
+	}
+
+	if o.writeTimeout != nil {
+		t.WriteTimeout = *o.writeTimeout
+	}
+
+	if o.dialTimeout != nil {
+		t.DialTimeout = *o.dialTimeout
+	}
+
+	if o.reconnectionInterval != nil {
+		t.ReconnectionInterval = *o.reconnectionInterval
+	}
+}
+
+// applyUdpTransporter also marks the nodes set by the options, t only derives the others from the IP addresses.
+func (o *options) applyUdpTransporter(t *UdpTransporter) {
+	o.applyTransporter(&t.baseTransporter)
+	t.dstNodeSet = o.dst.Node != 0
+	t.srcNodeSet = o.src.Node != 0
+}
+
+// applyHeader sets the gateway count and the addresses of header, zero nodes are left to the transporter.
+func (o *options) applyHeader(header *finsHeader) {
+	header.GCT = o.gct
+	o.dst.applyDestination(header)
+	o.src.applySource(header)
+}
@@ -0,0 +1,43 @@
+package fins
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewFinsOptions(t *testing.T) {
+	f := NewFins(PlcTypeNew, TransTypeUdp, "127.0.0.1:9600",
+		WithDestination(NodeAddress{Network: 1, Node: 10, Unit: 0x10}), WithSource(NodeAddress{Node: 20}), WithGatewayCount(7),
+		WithReadTimeout(time.Second), WithDialTimeout(time.Second), WithReconnectionInterval(0)).(*fins)
+
+	header := f.newHeader(true, 1)
+	assert.Equal(t, byte(7), header.GCT)
+	assert.Equal(t, []byte{1, 10, 0x10}, []byte{header.DNA, header.DA1, header.DA2})
+	assert.Equal(t, []byte{0, 20, 0}, []byte{header.SNA, header.SA1, header.SA2})
+
+	ut := f.transporter.(*UdpTransporter)
+	assert.Equal(t, time.Second, ut.ReadTimeout)
+	assert.Equal(t, 3*time.Second, ut.WriteTimeout)
+	assert.Equal(t, time.Second, ut.DialTimeout)
+	assert.Equal(t, time.Duration(0), ut.ReconnectionInterval)
+	assert.True(t, ut.dstNodeSet)
+	assert.True(t, ut.srcNodeSet)
+}
+
+func TestNewFinsInvalidOptions(t *testing.T) {
+	for _, opt := range []Option{
+		WithDestination(NodeAddress{Network: 0x80, Node: 1}),
+		WithDestination(NodeAddress{Network: 1}),
+		WithDestination(NodeAddress{Node: 1, Unit: 0x20}),
+		WithSource(NodeAddress{Node: 0xFF}),
+		WithSource(NodeAddress{Node: 1, Unit: 0xFE}),
+		WithGatewayCount(8),
+		WithReadTimeout(0),
+		WithReconnectionInterval(-time.Second),
+	} {
+		assert.Panics(t, func() {
+			NewFins(PlcTypeNew, TransTypeTcp, "127.0.0.1:9600", opt)
+		})
+	}
+}
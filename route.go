@@ -0,0 +1,68 @@
+package fins
+
+import (
+	"fmt"
+)
+
+// NodeAddress is the network, node and unit address of a FINS node,
+// the ranges are described in newFinsHeader.
+type NodeAddress struct {
+	Network byte
+	Node    byte
+	Unit    byte
+}
+
+func (na NodeAddress) String() string {
+	return fmt.Sprintf("%d.%d.0x%02X", na.Network, na.Node, na.Unit)
+}
+
+func (na NodeAddress) validateDestination() error {
+	if na.Network > 0x7F {
+		return fmt.Errorf("destination %s: network out of range 0 to 127", na)
+	}
+
+	// node 0 is left to the transporter, which only knows the nodes of the local network
+	if na.Network != 0 && na.Node == 0 {
+		return fmt.Errorf("destination %s: node of a remote network must be set", na)
+	}
+
+	if !validDestinationUnit(na.Unit) {
+		return fmt.Errorf("destination %s: unit must be 0x00, 0x10 to 0x1F, 0xE1 or 0xFE", na)
+	}
+
+	return nil
+}
+
+func (na NodeAddress) validateSource() error {
+	if na.Network > 0x7F {
+		return fmt.Errorf("source %s: network out of range 0 to 127", na)
+	}
+
+	if na.Node == 0xFF {
+		return fmt.Errorf("source %s: node is the broadcast address", na)
+	}
+
+	if !validSourceUnit(na.Unit) {
+		return fmt.Errorf("source %s: unit must be 0x00 or 0x10 to 0x1F", na)
+	}
+
+	return nil
+}
+
+// validDestinationUnit reports whether unit is the CPU Unit, a CPU Bus Unit, the Inner Board or the network unit.
+func validDestinationUnit(unit byte) bool {
+	return unit == 0x00 || (unit >= 0x10 && unit <= 0x1F) || unit == 0xE1 || unit == 0xFE
+}
+
+// validSourceUnit reports whether unit is the CPU Unit or a CPU Bus Unit.
+func validSourceUnit(unit byte) bool {
+	return unit == 0x00 || (unit >= 0x10 && unit <= 0x1F)
+}
+
+func (na NodeAddress) applyDestination(header *finsHeader) {
+	header.DNA, header.DA1, header.DA2 = na.Network, na.Node, na.Unit
+}
+
+func (na NodeAddress) applySource(header *finsHeader) {
+	header.SNA, header.SA1, header.SA2 = na.Network, na.Node, na.Unit
+}
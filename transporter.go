@@ -1,6 +1,7 @@
 package fins
 
 import (
+	"context"
 	"github.com/expgo/log"
 	"net"
 	"sync"
@@ -23,34 +24,59 @@ State
 type State int
 
 type Transporter interface {
-	Open() error
+	// Open connects to the plc, ctx bounds the dial and any handshake.
+	Open(ctx context.Context) error
 	Close() error
-	Write(header *finsHeader, data []byte) (int, error)
-	ReadHeader() (*respFinsHeader, error)
-	ReadData(buf []byte) (int, error)
+	// Write sends a frame, the socket write deadline is the earlier of WriteTimeout and the ctx deadline.
+	Write(ctx context.Context, header *finsHeader, data []byte) (int, error)
+	// ReadFrame blocks until a whole response frame is received and returns its header and data.
+	ReadFrame() (*respFinsHeader, []byte, error)
 	State() State
 	setState(state State, err error)
 	SetStateChangeCallback(callback func(oldState, newState State))
+	responseTimeout() time.Duration
 }
 
 type baseTransporter struct {
 	log.InnerLog
 	ReadTimeout          time.Duration `value:"3s"`
 	WriteTimeout         time.Duration `value:"3s"`
+	DialTimeout          time.Duration `value:"3s"`
 	ReconnectionInterval time.Duration `value:"10s"`
 	addr                 string
 	conn                 net.Conn
+	// connLock guards conn, which Open and Close replace while the reader uses it
+	connLock sync.RWMutex
 
 	reconnectTimer *time.Timer
-	state          State       `value:"unknown"`
-	self           Transporter `wire:"self"`
-	callback       func(oldState, newState State)
-	stateLock      sync.Mutex
-	running        atomic.Bool
+	// state is read without stateLock, which is held while the callback runs
+	state     atomic.Int32
+	self      Transporter `wire:"self"`
+	callback  func(oldState, newState State)
+	stateLock sync.Mutex
+	running   atomic.Bool
 }
 
 func (t *baseTransporter) State() State {
-	return t.state
+	return State(t.state.Load())
+}
+
+func (t *baseTransporter) getConn() net.Conn {
+	t.connLock.RLock()
+	defer t.connLock.RUnlock()
+
+	return t.conn
+}
+
+func (t *baseTransporter) setConn(conn net.Conn) {
+	t.connLock.Lock()
+	defer t.connLock.Unlock()
+
+	t.conn = conn
+}
+
+func (t *baseTransporter) responseTimeout() time.Duration {
+	return t.ReadTimeout
 }
 
 func (t *baseTransporter) SetStateChangeCallback(callback func(oldState, newState State)) {
@@ -82,13 +108,14 @@ func (t *baseTransporter) setState(state State, err error) {
 		t.startReconnectTimer()
 	}
 
+	oldState := t.State()
 	if t.callback != nil {
-		t.callback(t.state, state)
+		t.callback(oldState, state)
 	}
 
-	t.L.Infof("%s state change, old state: %s, new state: %s, err: %v", t.addr, t.state, state, err)
+	t.L.Infof("%s state change, old state: %s, new state: %s, err: %v", t.addr, oldState, state, err)
 
-	t.state = state
+	t.state.Store(int32(state))
 }
 
 func (t *baseTransporter) startReconnectTimer() {
@@ -111,5 +138,50 @@ func (t *baseTransporter) reconnect() {
 	}
 
 	_ = t.self.Close()
-	_ = t.self.Open()
+	_ = t.self.Open(context.Background())
+}
+
+// deadline returns the earlier of the ctx deadline and timeout from now.
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	ret := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(ret) {
+		return d
+	}
+
+	return ret
+}
+
+// watchContext interrupts the pending io by moving the deadline to the past when ctx is done,
+// stop must be called once the io returns.
+func watchContext(ctx context.Context, setDeadline func(t time.Time) error) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+
+		select {
+		case <-ctx.Done():
+			_ = setDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// contextError prefers the ctx error to the deadline error it caused.
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
 }
@@ -2,10 +2,12 @@ package fins
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/expgo/factory"
+	"io"
 	"net"
 	"time"
 )
@@ -44,44 +46,57 @@ type TcpTransporter struct {
 
 func newTcpTransport(addr string) *TcpTransporter {
 	return factory.NewBeforeInit[TcpTransporter](func(ret *TcpTransporter) {
-		ret.baseTransporter = baseTransporter{addr: addr}
+		ret.addr = addr
 	})
 }
 
-func (t *TcpTransporter) Open() (err error) {
+func (t *TcpTransporter) Open(ctx context.Context) (err error) {
 	if !t.running.CompareAndSwap(false, true) {
 		return nil
 	}
 
-	if t.state == StateConnected {
+	if t.State() == StateConnected {
 		return nil
 	}
 
+	// a failed Open leaves the transporter closed, so it can be opened again
+	defer func() {
+		if err != nil {
+			t.running.Store(false)
+		}
+	}()
+
 	t.setState(StateConnecting, nil)
-	dialer := net.Dialer{Timeout: 3 * time.Second}
-	t.conn, err = dialer.Dial("tcp", t.addr)
+	dialer := net.Dialer{Timeout: t.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", t.addr)
 	if err != nil {
 		t.L.Warnf("DialTCP %s failed: %v", t.addr, err)
 		t.setState(StateDisconnected, err)
 		return err
 	}
+	t.setConn(conn)
 
-	err = t.getDaSa()
-
-	if err == nil {
-		t.setState(StateConnected, nil)
+	err = t.getDaSa(ctx)
+	if err != nil {
+		_ = conn.Close()
+		t.setConn(nil)
+		return err
 	}
 
-	return err
+	t.setState(StateConnected, nil)
+
+	return nil
 }
 
-func (t *TcpTransporter) getDaSa() (err error) {
+func (t *TcpTransporter) getDaSa(ctx context.Context) (err error) {
 	defer func() {
 		if err != nil {
 			t.setState(StateDisconnected, err)
 		}
 	}()
 
+	conn := t.getConn()
+
 	tcpHeader := newTcpFinsHeader(TcpCommandNodeAddressClientToServer)
 	tcpHeader.Length = 12
 
@@ -96,12 +111,26 @@ func (t *TcpTransporter) getDaSa() (err error) {
 		return err
 	}
 
-	_, err = t.conn.Write(req.Bytes())
+	err = conn.SetDeadline(deadline(ctx, t.ReadTimeout))
 	if err != nil {
 		return err
 	}
 
-	respTcpHeader, err := t.ReadTcpHeader()
+	stop := watchContext(ctx, conn.SetDeadline)
+
+	// clear the deadline, frames are read by a long-running reader afterward
+	defer func() {
+		stop()
+		_ = conn.SetDeadline(time.Time{})
+		err = contextError(ctx, err)
+	}()
+
+	_, err = conn.Write(req.Bytes())
+	if err != nil {
+		return err
+	}
+
+	respTcpHeader, err := readTcpHeader(conn)
 	if err != nil {
 		return err
 	}
@@ -111,7 +140,7 @@ func (t *TcpTransporter) getDaSa() (err error) {
 	}
 
 	buf := make([]byte, 8)
-	_, err = t.conn.Read(buf)
+	_, err = io.ReadFull(conn, buf)
 	if err != nil {
 		return err
 	}
@@ -128,31 +157,39 @@ func (t *TcpTransporter) getDaSa() (err error) {
 func (t *TcpTransporter) Close() (err error) {
 	defer func() {
 		t.setState(StateConnectClosed, err)
-		t.conn = nil
+		t.setConn(nil)
 	}()
 
 	_ = t.baseTransporter.Close()
 
-	if t.conn == nil {
+	conn := t.getConn()
+	if conn == nil {
 		return nil
 	}
 
-	return t.conn.Close()
+	return conn.Close()
 }
 
-func (t *TcpTransporter) Write(header *finsHeader, data []byte) (n int, err error) {
-	if t.conn == nil || t.state == StateDisconnected {
+func (t *TcpTransporter) Write(ctx context.Context, header *finsHeader, data []byte) (n int, err error) {
+	conn := t.getConn()
+	if conn == nil || t.State() == StateDisconnected {
 		return 0, errors.New("tcp transporter not connected")
 	}
 
 	defer func() {
-		if err != nil {
+		// the connection is shared, a canceled caller only breaks it when a part of the frame was written
+		if err != nil && (ctx.Err() == nil || n > 0) {
 			t.setState(StateDisconnected, err)
 		}
 	}()
 
-	header.DA1 = t.da1
-	header.SA1 = t.sa1
+	// keep the nodes set by the options and the destination node of broadcast frames
+	if header.DA1 == 0 {
+		header.DA1 = t.da1
+	}
+	if header.SA1 == 0 {
+		header.SA1 = t.sa1
+	}
 
 	tcpHeader := newTcpFinsHeader(TcpCommandFrameSend)
 	tcpHeader.Length = uint32(len(data)) + 18
@@ -176,17 +213,25 @@ func (t *TcpTransporter) Write(header *finsHeader, data []byte) (n int, err erro
 		}
 	}
 
-	err = t.conn.SetWriteDeadline(time.Now().Add(t.WriteTimeout))
+	err = conn.SetWriteDeadline(deadline(ctx, t.WriteTimeout))
 	if err != nil {
 		return 0, err
 	}
 
-	return t.conn.Write(buf.Bytes())
+	stop := watchContext(ctx, conn.SetWriteDeadline)
+	n, err = conn.Write(buf.Bytes())
+	stop()
+
+	return n, contextError(ctx, err)
 }
 
 func (t *TcpTransporter) ReadTcpHeader() (tcpHeader *tcpFinsHeader, err error) {
+	return readTcpHeader(t.getConn())
+}
+
+func readTcpHeader(conn net.Conn) (tcpHeader *tcpFinsHeader, err error) {
 	tcpHeaderBuf := make([]byte, 4*4)
-	_, err = t.ReadData(tcpHeaderBuf)
+	_, err = io.ReadFull(conn, tcpHeaderBuf)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +248,7 @@ func (t *TcpTransporter) ReadTcpHeader() (tcpHeader *tcpFinsHeader, err error) {
 	if tcpHeader.ErrorCode != 0 {
 		if tcpHeader.Length > 8 {
 			buf := make([]byte, tcpHeader.Length-8)
-			_, _ = t.conn.Read(buf)
+			_, _ = io.ReadFull(conn, buf)
 		}
 		return nil, fmt.Errorf("FINS error code: %d", tcpHeader.ErrorCode)
 	}
@@ -211,52 +256,40 @@ func (t *TcpTransporter) ReadTcpHeader() (tcpHeader *tcpFinsHeader, err error) {
 	return tcpHeader, nil
 }
 
-func (t *TcpTransporter) ReadHeader() (header *respFinsHeader, err error) {
-	if t.conn == nil || t.state == StateDisconnected {
-		return nil, errors.New("tcp transporter not connected")
+func (t *TcpTransporter) ReadFrame() (header *respFinsHeader, data []byte, err error) {
+	conn := t.getConn()
+	if conn == nil || t.State() == StateDisconnected {
+		return nil, nil, errors.New("tcp transporter not connected")
 	}
 
 	defer func() {
-		if err != nil {
+		// a reader of a replaced connection must not change the state of the new one
+		if err != nil && t.getConn() == conn {
 			t.setState(StateDisconnected, err)
 		}
 	}()
 
-	_, err = t.ReadTcpHeader()
+	tcpHeader, err := readTcpHeader(conn)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	headerBuf := make([]byte, respHeaderSize)
-	_, err = t.ReadData(headerBuf)
-	if err != nil {
-		return nil, err
+	// length counts the command and error code fields of the tcp header
+	if tcpHeader.Length < 8+respHeaderSize {
+		return nil, nil, fmt.Errorf("invalid tcp header length %d", tcpHeader.Length)
 	}
 
-	header = &respFinsHeader{}
-	err = binary.Read(bytes.NewReader(headerBuf), binary.BigEndian, header)
+	buf := make([]byte, tcpHeader.Length-8)
+	_, err = io.ReadFull(conn, buf)
 	if err != nil {
-		return nil, err
-	}
-
-	return header, nil
-}
-
-func (t *TcpTransporter) ReadData(buf []byte) (n int, err error) {
-	if t.conn == nil || t.state == StateDisconnected {
-		return 0, errors.New("tcp transporter not connected")
+		return nil, nil, err
 	}
 
-	defer func() {
-		if err != nil {
-			t.setState(StateDisconnected, err)
-		}
-	}()
-
-	err = t.conn.SetReadDeadline(time.Now().Add(t.ReadTimeout))
+	header = &respFinsHeader{}
+	err = binary.Read(bytes.NewReader(buf[:respHeaderSize]), binary.BigEndian, header)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
 
-	return t.conn.Read(buf)
+	return header, buf[respHeaderSize:], nil
 }
@@ -0,0 +1,207 @@
+package fins
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"github.com/expgo/factory"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTcpTransporterWriteContext(t *testing.T) {
+	client, server := net.Pipe()
+	defer func() {
+		_ = client.Close()
+		_ = server.Close()
+	}()
+
+	tt := &TcpTransporter{baseTransporter: baseTransporter{conn: client, WriteTimeout: time.Minute}}
+	tt.state.Store(int32(StateConnected))
+
+	// nobody reads the pipe, so the write blocks until ctx is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := tt.Write(ctx, newFinsHeader(DataClassCommand, true, 1), []byte{0x01, 0x01})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	d, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, d, deadline(ctx, time.Minute))
+}
+
+func TestUdpTransporterIpNode(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 232)})
+	if err != nil {
+		t.Skipf("listen on 127.0.0.232 failed: %v", err)
+	}
+	defer func() {
+		_ = server.Close()
+	}()
+
+	f := NewFins(PlcTypeNew, TransTypeUdp, server.LocalAddr().String()).(*fins)
+	assert.NoError(t, f.Open())
+	defer func() {
+		_ = f.Close()
+	}()
+
+	ut := f.transporter.(*UdpTransporter)
+	assert.Equal(t, byte(232), ut.da1)
+	assert.Equal(t, ut.getConn().LocalAddr().(*net.UDPAddr).IP.To4()[3], ut.sa1)
+
+	_, err = ipNode(&net.UDPAddr{IP: net.ParseIP("::1")})
+	assert.Error(t, err)
+	_, err = ipNode(&net.UDPAddr{IP: net.IPv4(192, 168, 1, 255)})
+	assert.Error(t, err)
+
+	// node 0 can not be derived from 127.0.1.0, it must be set by the options
+	f = NewFins(PlcTypeNew, TransTypeUdp, "127.0.1.0:9600", WithReconnectionInterval(0)).(*fins)
+	assert.Error(t, f.Open())
+	assert.Equal(t, StateDisconnected, f.transporter.State())
+
+	f = NewFins(PlcTypeNew, TransTypeUdp, "127.0.1.0:9600", WithDestination(NodeAddress{Node: 10})).(*fins)
+	assert.NoError(t, f.Open())
+	assert.NoError(t, f.Close())
+}
+
+func TestFinsReadContextKeepsConnection(t *testing.T) {
+	client, server := net.Pipe()
+	defer func() {
+		_ = server.Close()
+	}()
+
+	tt := newTcpTransport("pipe")
+	tt.setConn(client)
+	tt.state.Store(int32(StateConnected))
+	tt.running.Store(true)
+
+	f := factory.New[fins]()
+	f.plcType = PlcTypeNew
+	f.options, _ = newOptions(nil)
+	f.mux = &mux{}
+	f.transporter = tt
+	defer func() {
+		_ = f.Close()
+	}()
+
+	address := &FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}
+
+	// canceled before the request is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := f.ReadContext(ctx, address, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateConnected, tt.State())
+
+	// canceled while the write is blocked, nothing of the frame is written
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = f.ReadContext(ctx, address, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateConnected, tt.State())
+}
+
+// servePipe answers every frame sent on conn with a response carrying data.
+func servePipe(conn net.Conn, data []byte) {
+	for {
+		tcpHeader, err := readTcpHeader(conn)
+		if err != nil {
+			return
+		}
+
+		req := make([]byte, tcpHeader.Length-8)
+		if _, err = io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		// the fins header and command code of the request, then the end code and data
+		resp := append(append([]byte{}, req[:12]...), 0x00, 0x00)
+		resp = append(resp, data...)
+		resp[0] |= 0b01000000
+
+		respHeader := newTcpFinsHeader(TcpCommandFrameSend)
+		respHeader.Length = uint32(len(resp)) + 8
+
+		buf := &bytes.Buffer{}
+		_ = binary.Write(buf, binary.BigEndian, respHeader)
+		_, _ = buf.Write(resp)
+		if _, err = conn.Write(buf.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+func TestTcpTransporterStateRace(t *testing.T) {
+	client, server := net.Pipe()
+	defer func() {
+		_ = server.Close()
+	}()
+	go servePipe(server, []byte{0x12, 0x34})
+
+	tt := newTcpTransport("pipe")
+	tt.setConn(client)
+	tt.state.Store(int32(StateConnected))
+	tt.running.Store(true)
+
+	f := factory.New[fins]()
+	f.plcType = PlcTypeNew
+	f.options, _ = newOptions(nil)
+	f.mux = &mux{}
+	f.transporter = tt
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// the state changes while the reader and the writers check it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			tt.setState(StateConnected, nil)
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		ret, err := f.Read(&FinAddress{AreaCode: MemoryAreaDMWord, Address: 0}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(0x1234), ret[0].Uint16())
+	}
+
+	<-done
+	assert.Equal(t, StateConnected, tt.State())
+}
+
+func TestTransporterOpenFailure(t *testing.T) {
+	// the listener closes every connection before the node address handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	for _, f := range []Fins{
+		NewFins(PlcTypeNew, TransTypeTcp, listener.Addr().String(), WithReconnectionInterval(0)),
+		NewFins(PlcTypeNew, TransTypeUdp, "no port", WithReconnectionInterval(0)),
+	} {
+		// a second Open tries again instead of reporting the failed one as opened
+		assert.Error(t, f.Open())
+		assert.Error(t, f.Open())
+		assert.Equal(t, StateDisconnected, f.(*fins).transporter.State())
+	}
+}
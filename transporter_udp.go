@@ -2,40 +2,93 @@ package fins
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"github.com/expgo/factory"
 	"net"
-	"time"
 )
 
+// udpMaxFrameSize is larger than the max FINS frame of 2012 bytes
+const udpMaxFrameSize = 4096
+
 type UdpTransporter struct {
 	baseTransporter
 	da1 byte
 	sa1 byte
+	// dstNodeSet and srcNodeSet are set when the options give the node, so it needs not be derived
+	dstNodeSet bool
+	srcNodeSet bool
 }
 
 func newUdpTransport(addr string) *UdpTransporter {
 	return factory.NewBeforeInit[UdpTransporter](func(ret *UdpTransporter) {
-		ret.baseTransporter = baseTransporter{addr: addr}
-		ret.da1 = 0xe8
-		ret.sa1 = 0x38
+		ret.addr = addr
 	})
 }
 
-func (t *UdpTransporter) Open() (err error) {
-	if t.state == StateConnected {
+// ipNode converts addr to a node address with the last octet of its IPv4 address,
+// as the automatic address conversion of Ethernet Units does.
+func ipNode(addr net.Addr) (byte, error) {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return 0, fmt.Errorf("not an udp address: %v", addr)
+	}
+
+	ip := udpAddr.IP.To4()
+	if ip == nil {
+		return 0, fmt.Errorf("not an IPv4 address: %v", addr)
+	}
+
+	if ip[3] == 0 || ip[3] == 0xFF {
+		return 0, fmt.Errorf("node address of %v out of range 1 to 254", addr)
+	}
+
+	return ip[3], nil
+}
+
+func (t *UdpTransporter) Open(ctx context.Context) (err error) {
+	if !t.running.CompareAndSwap(false, true) {
 		return nil
 	}
 
+	if t.State() == StateConnected {
+		return nil
+	}
+
+	// a failed Open leaves the transporter closed, so it can be opened again
+	defer func() {
+		if err != nil {
+			t.running.Store(false)
+		}
+	}()
+
 	t.setState(StateConnecting, nil)
-	dailer := net.Dialer{Timeout: 3 * time.Second}
-	t.conn, err = dailer.Dial("udp", t.addr)
+	dailer := net.Dialer{Timeout: t.DialTimeout}
+	conn, err := dailer.DialContext(ctx, "udp", t.addr)
 	if err != nil {
 		t.L.Warnf("DialUDP %s failed: %v", t.addr, err)
 		t.setState(StateDisconnected, err)
 		return err
 	}
+	t.setConn(conn)
+
+	// a node is only required when the options do not set it
+	var nodeErr error
+	if t.da1, nodeErr = ipNode(conn.RemoteAddr()); nodeErr != nil && !t.dstNodeSet {
+		err = fmt.Errorf("derive destination node failed: %w", nodeErr)
+	} else if t.sa1, nodeErr = ipNode(conn.LocalAddr()); nodeErr != nil && !t.srcNodeSet {
+		err = fmt.Errorf("derive source node failed: %w", nodeErr)
+	}
+
+	if err != nil {
+		t.L.Warnf("%v", err)
+		_ = conn.Close()
+		t.setConn(nil)
+		t.setState(StateDisconnected, err)
+		return err
+	}
 
 	t.setState(StateConnected, nil)
 
@@ -45,31 +98,39 @@ func (t *UdpTransporter) Open() (err error) {
 func (t *UdpTransporter) Close() (err error) {
 	defer func() {
 		t.setState(StateConnectClosed, err)
-		t.conn = nil
+		t.setConn(nil)
 	}()
 
 	_ = t.baseTransporter.Close()
 
-	if t.conn == nil {
+	conn := t.getConn()
+	if conn == nil {
 		return nil
 	}
 
-	return t.conn.Close()
+	return conn.Close()
 }
 
-func (t *UdpTransporter) Write(header *finsHeader, data []byte) (n int, err error) {
-	if t.conn == nil || t.state == StateDisconnected {
+func (t *UdpTransporter) Write(ctx context.Context, header *finsHeader, data []byte) (n int, err error) {
+	conn := t.getConn()
+	if conn == nil || t.State() == StateDisconnected {
 		return 0, errors.New("udp transporter not connected")
 	}
 
 	defer func() {
-		if err != nil {
+		// the connection is shared, a canceled caller only breaks it when a part of the frame was written
+		if err != nil && (ctx.Err() == nil || n > 0) {
 			t.setState(StateDisconnected, err)
 		}
 	}()
 
-	header.DA1 = t.da1
-	header.SA1 = t.sa1
+	// keep the nodes set by the options and the destination node of broadcast frames
+	if header.DA1 == 0 {
+		header.DA1 = t.da1
+	}
+	if header.SA1 == 0 {
+		header.SA1 = t.sa1
+	}
 
 	buf := &bytes.Buffer{}
 
@@ -85,47 +146,47 @@ func (t *UdpTransporter) Write(header *finsHeader, data []byte) (n int, err erro
 		}
 	}
 
-	err = t.conn.SetWriteDeadline(time.Now().Add(t.WriteTimeout))
+	err = conn.SetWriteDeadline(deadline(ctx, t.WriteTimeout))
 	if err != nil {
 		return 0, err
 	}
 
-	return t.conn.Write(buf.Bytes())
+	stop := watchContext(ctx, conn.SetWriteDeadline)
+	n, err = conn.Write(buf.Bytes())
+	stop()
+
+	return n, contextError(ctx, err)
 }
 
-func (t *UdpTransporter) ReadHeader() (header *respFinsHeader, err error) {
+func (t *UdpTransporter) ReadFrame() (header *respFinsHeader, data []byte, err error) {
+	conn := t.getConn()
+	if conn == nil || t.State() == StateDisconnected {
+		return nil, nil, errors.New("udp transporter not connected")
+	}
+
 	defer func() {
-		if err != nil {
+		// a reader of a replaced connection must not change the state of the new one
+		if err != nil && t.getConn() == conn {
 			t.setState(StateDisconnected, err)
 		}
 	}()
 
-	headerBuf := make([]byte, respHeaderSize)
-	_, err = t.ReadData(headerBuf)
+	// a frame is a whole datagram, it must be read at once
+	buf := make([]byte, udpMaxFrameSize)
+	n, err := conn.Read(buf)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	header = &respFinsHeader{}
-	err = binary.Read(bytes.NewReader(headerBuf), binary.BigEndian, header)
-	if err != nil {
-		return nil, err
+	if n < respHeaderSize {
+		return nil, nil, fmt.Errorf("udp frame too short: %d bytes", n)
 	}
 
-	return header, nil
-}
-
-func (t *UdpTransporter) ReadData(buf []byte) (n int, err error) {
-	defer func() {
-		if err != nil {
-			t.setState(StateDisconnected, err)
-		}
-	}()
-
-	err = t.conn.SetReadDeadline(time.Now().Add(t.ReadTimeout))
+	header = &respFinsHeader{}
+	err = binary.Read(bytes.NewReader(buf[:respHeaderSize]), binary.BigEndian, header)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
 
-	return t.conn.Read(buf)
+	return header, buf[respHeaderSize:n], nil
 }